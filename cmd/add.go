@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	untranslatable bool
+	localeValues   []string
+	forceA         bool
+)
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+	addCmd.Flags().StringP("key", "k", "", "Key of the new string (no spaces allowed, lowercases letters and underscores only)")
+	addCmd.Flags().StringP("value", "v", "", "Value of the string in the default values/strings.xml (closed in quotes)")
+	addCmd.Flags().BoolVar(&untranslatable, "untranslatable", false, "Mark the string as translatable=\"false\"")
+	addCmd.Flags().StringArrayVarP(&localeValues, "locale", "l", []string{}, "Translation provided by hand as locale=value, e.g. pt-rBR=\"Olá\", repeat the flag for each locale")
+	addCmd.Flags().BoolVar(&forceA, "force", false, "Substitute the value if the key already exists in a file")
+	addResDirectoryFlags(addCmd)
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a string to the default locale, and optionally to other locales, without translating it",
+	RunE:  runAddCmd,
+}
+
+func runAddCmd(cmd *cobra.Command, args []string) error {
+	err := internal.BlockIfNotAndroidProject()
+	if err != nil {
+		return err
+	}
+
+	err = loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	key := cmd.Flag("key").Value.String()
+	if !internal.IsKeyValidPrintMessage(key) {
+		return fmt.Errorf("invalid key")
+	}
+
+	value := cmd.Flag("value").Value.String()
+	if value == "" {
+		return fmt.Errorf("you need to pass the value through --value flag to use this command")
+	}
+
+	if untranslatable && len(localeValues) > 0 {
+		return fmt.Errorf("untranslatable strings only exist in the default locale, --locale cannot be used with --untranslatable")
+	}
+
+	values, err := parseLocaleValues(localeValues)
+	if err != nil {
+		return err
+	}
+
+	resDirectory, err := resDirectoryFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	translations, err := internal.SelectResDirectoryAndReturnTranslations(resDirectory)
+	if err != nil {
+		return err
+	}
+
+	// Value of each file, only the default locale and the given locales are changed
+	files := map[string]string{}
+	hasDefault := false
+	for _, t := range translations {
+		if t.IsDefault() {
+			files[t.Path] = value
+			hasDefault = true
+			continue
+		}
+
+		if v, ok := values[t.Qualifier()]; ok {
+			files[t.Path] = v
+			delete(values, t.Qualifier())
+		}
+	}
+
+	if !hasDefault {
+		return fmt.Errorf("no default values/strings.xml found in the resource directory")
+	}
+
+	if len(values) > 0 {
+		return fmt.Errorf("no strings.xml found in the resource directory for the locales %v", strings.Join(slices.Sorted(maps.Keys(values)), ", "))
+	}
+
+	changes := internal.NewFileChanges()
+	for _, t := range translations {
+		v, ok := files[t.Path]
+		if !ok {
+			continue
+		}
+
+		r, err := internal.GetResourcesFromPathXML(t.Path)
+		if err != nil {
+			return err
+		}
+
+		if r.ContainsStringByKey(key) && !forceA {
+			return fmt.Errorf("key <%v> already exists in %v, use --force to substitute it", key, t.Path)
+		}
+
+		r = addStringValue(r, key, internal.ResourceValue(v))
+		// A substituted string keeps its translatable attribute unless the flag is given
+		if t.IsDefault() && cmd.Flags().Changed("untranslatable") {
+			r = r.SetStringTranslatable(key, !untranslatable)
+		}
+
+		output, err := r.RenderXML(t.Path)
+		if err != nil {
+			return err
+		}
+
+		err = changes.Set(t.Path, output)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = changes.Write()
+	if err != nil {
+		return err
+	}
+
+	for _, t := range translations {
+		if v, ok := files[t.Path]; ok {
+			fmt.Fprintf(cmd.OutOrStdout(), "Added <%v> to %v: %v\n", key, t.Path, v)
+		}
+	}
+
+	return nil
+}
+
+// Values given as locale=value by qualifier, e.g. pt-rBR
+func parseLocaleValues(entries []string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, entry := range entries {
+		qualifier, value, ok := strings.Cut(entry, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid --locale %q, use locale=value, e.g. pt-rBR=\"Olá\"", entry)
+		}
+
+		if _, err := internal.TranslationFromQualifier(qualifier); err != nil {
+			return nil, fmt.Errorf("invalid --locale %q: %v", entry, err)
+		}
+
+		if _, ok := values[qualifier]; ok {
+			return nil, fmt.Errorf("locale %v given more than once", qualifier)
+		}
+
+		values[qualifier] = value
+	}
+
+	return values, nil
+}
+
+// Create or substitute the string, appending new strings when the sort policy is none
+func addStringValue(r internal.Resources, key, value string) internal.Resources {
+	if !r.ContainsStringByKey(key) && !projectConfig.SortsByKey() {
+		return r.AppendNewString(internal.String{
+			XMLName: xml.Name{Local: "string"},
+			Key:     key,
+			Value:   value,
+		})
+	}
+
+	return r.CreateOrSubstituteStringByKey(key, value)
+}
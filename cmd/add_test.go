@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddCmd_non_android_project_directory(t *testing.T) {
+	root := &cobra.Command{Use: "add", RunE: addCmd.RunE}
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, "current directory is not an android project", err.Error())
+}
+
+func writeAddProject(t *testing.T) {
+	writeProject(t, map[string]string{
+		"build.gradle":                  "",
+		appStringsPath("values"):        "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"c\">C</string>\n</resources>\n",
+		appStringsPath("values-pt-rBR"): "<resources>\n    <string name=\"a\">A</string>\n</resources>\n",
+		appStringsPath("values-es"):     "<resources>\n    <string name=\"a\">A</string>\n</resources>\n",
+	})
+}
+
+func newAddCmd(flags map[string]string) *cobra.Command {
+	root := &cobra.Command{Use: "add", RunE: addCmd.RunE}
+	root.Flags().StringP("key", "k", flags["key"], "")
+	root.Flags().StringP("value", "v", flags["value"], "")
+	root.Flags().BoolVar(&untranslatable, "untranslatable", false, "")
+	root.Flags().String("res", "", "")
+	root.Flags().String("module", "", "")
+
+	return root
+}
+
+func resetAddFlags() {
+	untranslatable = false
+	localeValues = []string{}
+	forceA = false
+}
+
+func TestAddCmd_default_and_manual_translations(t *testing.T) {
+	writeAddProject(t)
+
+	localeValues = []string{"pt-rBR=B em português"}
+	defer resetAddFlags()
+
+	var output bytes.Buffer
+	root := newAddCmd(map[string]string{"key": "b", "value": "Palmeiras' B"})
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+
+	expected := map[string]string{
+		"values":        "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\">Palmeiras\\' B</string>\n    <string name=\"c\">C</string>\n</resources>\n",
+		"values-pt-rBR": "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\">B em português</string>\n</resources>\n",
+		"values-es":     "<resources>\n    <string name=\"a\">A</string>\n</resources>\n",
+	}
+	for dir, content := range expected {
+		got, _ := os.ReadFile(filepath.Join("app", "src", "main", "res", dir, "strings.xml"))
+		assert.Equal(t, content, string(got), dir)
+	}
+}
+
+func TestAddCmd_escapes_xml(t *testing.T) {
+	writeAddProject(t)
+
+	localeValues = []string{"pt-rBR=Tom & Jerry <3"}
+	defer resetAddFlags()
+
+	root := newAddCmd(map[string]string{"key": "b", "value": "Tom & Jerry, <b>bold</b> &amp; \"fun\""})
+	root.SetOut(&bytes.Buffer{})
+
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile(appStringsPath("values"))
+	assert.Contains(t, string(got), `<string name="b">Tom &amp; Jerry, <b>bold</b> &amp; \"fun\"</string>`)
+
+	got, _ = os.ReadFile(appStringsPath("values-pt-rBR"))
+	assert.Contains(t, string(got), `<string name="b">Tom &amp; Jerry &lt;3</string>`)
+
+	// The next command can read the files
+	_, err := internal.GetResourcesFromPathXML(appStringsPath("values-pt-rBR"))
+	assert.NoError(t, err)
+}
+
+func TestAddCmd_untranslatable(t *testing.T) {
+	writeAddProject(t)
+
+	defer resetAddFlags()
+
+	root := newAddCmd(map[string]string{"key": "b", "value": "Palmeiras"})
+	root.Flags().Set("untranslatable", "true")
+	root.SetOut(&bytes.Buffer{})
+
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile("app/src/main/res/values/strings.xml")
+	assert.Equal(t, "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\" translatable=\"false\">Palmeiras</string>\n    <string name=\"c\">C</string>\n</resources>\n", string(got))
+}
+
+func TestAddCmd_existing_key(t *testing.T) {
+	writeAddProject(t)
+	defer resetAddFlags()
+
+	root := newAddCmd(map[string]string{"key": "a", "value": "New A"})
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "key <a> already exists in")
+	assert.Contains(t, err.Error(), "use --force to substitute it")
+
+	forceA = true
+	root = newAddCmd(map[string]string{"key": "a", "value": "New A"})
+	root.SetOut(&bytes.Buffer{})
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile("app/src/main/res/values/strings.xml")
+	assert.Equal(t, "<resources>\n    <string name=\"a\">New A</string>\n    <string name=\"c\">C</string>\n</resources>\n", string(got))
+}
+
+func TestAddCmd_force_keeps_untranslatable(t *testing.T) {
+	writeAddProject(t)
+	os.WriteFile(appStringsPath("values"), []byte("<resources>\n    <string name=\"a\" translatable=\"false\">A</string>\n</resources>\n"), 0o644)
+
+	forceA = true
+	defer resetAddFlags()
+
+	root := newAddCmd(map[string]string{"key": "a", "value": "New A"})
+	root.SetOut(&bytes.Buffer{})
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile(appStringsPath("values"))
+	assert.Equal(t, "<resources>\n    <string name=\"a\" translatable=\"false\">New A</string>\n</resources>\n", string(got))
+
+	root = newAddCmd(map[string]string{"key": "a", "value": "New A"})
+	root.SetOut(&bytes.Buffer{})
+	root.Flags().Set("untranslatable", "false")
+	assert.NoError(t, root.Execute())
+
+	got, _ = os.ReadFile(appStringsPath("values"))
+	assert.Equal(t, "<resources>\n    <string name=\"a\">New A</string>\n</resources>\n", string(got))
+}
+
+func TestAddCmd_unknown_locale(t *testing.T) {
+	writeAddProject(t)
+
+	localeValues = []string{"fr=B"}
+	defer resetAddFlags()
+
+	root := newAddCmd(map[string]string{"key": "b", "value": "B"})
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, "no strings.xml found in the resource directory for the locales fr", err.Error())
+}
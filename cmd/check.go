@@ -1,20 +1,56 @@
 package cmd
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
 
 	"polyglot/cmd/internal"
 
 	"github.com/spf13/cobra"
 )
 
-var allModulesC bool
+var (
+	allModulesC   bool
+	checkRules    []string
+	checkFormat   string
+	failOn        string
+	baselinePath  string
+	writeBaseline bool
+	usageSources  []string
+)
+
+// Formats of the check report
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSarif = "sarif"
+)
+
+var checkFormats = []string{FormatText, FormatJSON, FormatSarif}
+
+// Title printed before the findings of each rule in the text report
+var ruleTitles = map[string]string{
+	internal.RuleUnsorted:            "Checking if translation files are sorted by key...",
+	internal.RuleUnused:              "Searching for *possible* unused keys in all resources...",
+	internal.RulePlaceholderMismatch: "Checking for placeholder mismatches with the default locale...",
+	internal.RuleMissingTranslation:  "Checking for *possible* missing translations between files...",
+}
 
 func init() {
-	// TODO: Add flags to select which type of normalization to check
-	// If none selected check all
 	rootCmd.AddCommand(checkCmd)
 	checkCmd.Flags().BoolVar(&allModulesC, "all", false, "Check all translations files of all project modules")
+	checkCmd.Flags().StringSliceVar(&checkRules, "rule", []string{}, fmt.Sprintf("Only check the given rules, comma separated or repeated (%v), overrides the rules of %v", strings.Join(internal.CheckRules(), ", "), internal.ConfigFileName))
+	checkCmd.Flags().StringVar(&checkFormat, "format", FormatText, fmt.Sprintf("Format of the report: %v", strings.Join(checkFormats, ", ")))
+	checkCmd.Flags().StringVar(&failOn, "fail-on", internal.SeverityError, fmt.Sprintf("Exit with an error when there are findings of this severity or higher: %v", strings.Join(internal.Severities(), ", ")))
+	checkCmd.Flags().StringVar(&baselinePath, "baseline", "", fmt.Sprintf("Path of the baseline file, findings in it are not reported (defaults to %v)", internal.DefaultBaselineFileName))
+	checkCmd.Flags().BoolVar(&writeBaseline, "write-baseline", false, "Write the current findings to the baseline file instead of reporting them")
+	checkCmd.Flags().StringSliceVar(&usageSources, "usage-source", []string{}, fmt.Sprintf("Only search the given sources for usages of keys, comma separated or repeated (%v), overrides the usage sources of %v", strings.Join(internal.UsageSources(), ", "), internal.ConfigFileName))
+	addResDirectoryFlags(checkCmd)
 }
 
 var checkCmd = &cobra.Command{
@@ -29,7 +65,37 @@ func runCheckCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	translations, err := internal.GetTranslations(allModulesC)
+	err = loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range checkRules {
+		if !slices.Contains(internal.CheckRules(), rule) {
+			return fmt.Errorf("unknown rule %q, available rules: %v", rule, strings.Join(internal.CheckRules(), ", "))
+		}
+	}
+
+	for _, source := range usageSources {
+		if !slices.Contains(internal.UsageSources(), source) {
+			return fmt.Errorf("unknown usage source %q, available sources: %v", source, strings.Join(internal.UsageSources(), ", "))
+		}
+	}
+
+	if !slices.Contains(checkFormats, checkFormat) {
+		return fmt.Errorf("unknown format %q, available formats: %v", checkFormat, strings.Join(checkFormats, ", "))
+	}
+
+	if !slices.Contains(internal.Severities(), failOn) {
+		return fmt.Errorf("unknown severity %q, available severities: %v", failOn, strings.Join(internal.Severities(), ", "))
+	}
+
+	resDirectory, err := resDirectoryFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	translations, err := internal.GetTranslations(allModulesC, resDirectory)
 	if err != nil || translations == nil {
 		if err != nil {
 			return err
@@ -39,64 +105,243 @@ func runCheckCmd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Only the report is written to stdout, so it can be piped when the format is json or sarif
 	allResources := internal.ListResources{}
-
-	keys := make(map[string]struct{})
-
-	// CHECK: Traslations files are sorted by key
-	fmt.Printf("Checking if translation files are sorted by key...\n")
+	checkedFiles := map[string]bool{}
+	unreadable := []string{}
 	for _, t := range translations {
 		r, err := internal.GetResourcesFromPathXML(t.Path)
 		if err != nil {
-			fmt.Println(err)
+			fmt.Fprintf(os.Stderr, "%v: %v\n", internal.FindingPath(t.Path), err)
+			unreadable = append(unreadable, internal.FindingPath(t.Path))
 			continue
 		}
 
 		allResources = append(allResources, r)
+		checkedFiles[internal.FindingPath(t.Path)] = true
+	}
+
+	findings := checkFindings(allResources)
+	internal.SortFindings(findings)
 
-		if !r.IsSortedByKey() {
-			fmt.Printf("\tFAIL: File \"%v\" is not sorted by key\n", t.Path)
-		} else {
-			fmt.Printf("\tPASS: File \"%v\" is sorted by key\n", t.Path)
+	path := cmp.Or(baselinePath, projectConfig.Check.Baseline, internal.DefaultBaselineFileName)
+
+	// A baseline would forget the findings of the files that cannot be read
+	if writeBaseline && len(unreadable) > 0 {
+		return fmt.Errorf("could not parse %v resource files, fix them before writing the baseline: %v", len(unreadable), strings.Join(unreadable, ", "))
+	}
+
+	if writeBaseline {
+		err = internal.NewBaseline(findings).Write(path)
+		if err != nil {
+			return err
 		}
 
-		// Add all keys of all resources to check for possible unused keys
-		for _, key := range r.Strings {
-			// Set is used as a map with no values
-			keys[key.Key] = struct{}{}
+		fmt.Fprintf(cmd.OutOrStdout(), "Wrote %v findings to the baseline %v\n", len(findings), path)
+		return nil
+	}
+
+	// A baseline given with --baseline must exist, the configured or default one is optional
+	var fixed []internal.BaselineEntry
+	baseline, err := internal.ReadBaseline(path)
+	if err == nil {
+		findings, fixed = baseline.Filter(findings, func(e internal.BaselineEntry) bool {
+			return isRuleEnabled(e.RuleID) && checkedFiles[e.File]
+		})
+	} else if !os.IsNotExist(err) || baselinePath != "" {
+		return err
+	}
+
+	err = printFindings(cmd.OutOrStdout(), findings, fixed)
+	if err != nil {
+		return err
+	}
+
+	// The rules cannot check a file that does not parse, so it fails the
+	// check whatever the severity given with --fail-on
+	if len(unreadable) > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("could not parse %v resource files: %v", len(unreadable), strings.Join(unreadable, ", "))
+	}
+
+	failures := 0
+	for _, f := range findings {
+		if internal.IsSeverityAtLeast(f.Severity, failOn) {
+			failures++
 		}
 	}
 
+	if failures > 0 {
+		// The report already explains the failure, so the usage is not printed
+		cmd.SilenceUsage = true
+		return fmt.Errorf("found %v findings with severity %v or higher", failures, failOn)
+	}
+
+	return nil
+}
+
+// Rules given with --rule or, if not set, the ones of the project configuration
+func isRuleEnabled(rule string) bool {
+	if len(checkRules) > 0 {
+		return slices.Contains(checkRules, rule)
+	}
+
+	return projectConfig.IsRuleEnabled(rule)
+}
+
+// Severity of the rule in the project configuration. A rule turned off in the
+// configuration but given with --rule keeps its default severity.
+func ruleSeverity(rule string) string {
+	severity := projectConfig.RuleSeverity(rule)
+	if severity == internal.SeverityOff {
+		return internal.DefaultSeverity(rule)
+	}
+
+	return severity
+}
+
+// Findings of every enabled rule
+func checkFindings(allResources internal.ListResources) []internal.Finding {
+	findings := []internal.Finding{}
+
+	// CHECK: Traslations files are sorted by key
+	if isRuleEnabled(internal.RuleUnsorted) {
+		findings = append(findings, allResources.UnsortedFindings()...)
+	}
+
+	// Excluded keys are sorted as any other key, but not reported by the other rules
+	allResources = withoutExcludedKeys(allResources)
+
 	// CHECK: Find possible unused keys
-	checkUnusedKeys(keys)
+	if isRuleEnabled(internal.RuleUnused) {
+		findings = append(findings, checkUnusedKeys(allResources)...)
+	}
+
+	// CHECK: Format arguments of translations match the default locale
+	if isRuleEnabled(internal.RulePlaceholderMismatch) {
+		findings = append(findings, allResources.PlaceholderFindings()...)
+	}
 
 	// CHECK: Missing translations between files
-	fmt.Printf("Checking for *possible* missing translations between files...\n")
-	missingTranslationRelatory := allResources.CheckMissingTranslations().CheckMissingTranslationsRelatory()
-	fmt.Println(missingTranslationRelatory)
+	if isRuleEnabled(internal.RuleMissingTranslation) {
+		findings = append(findings, allResources.MissingTranslationFindings()...)
+	}
 
-	return nil
+	for i := range findings {
+		findings[i].Severity = ruleSeverity(findings[i].RuleID)
+	}
+
+	return findings
 }
 
-func checkUnusedKeys(keys map[string]struct{}) {
-	if internal.IsWindows() {
-		fmt.Println("Checking for unused keys is not supported on Windows")
-		return
+func withoutExcludedKeys(allResources internal.ListResources) internal.ListResources {
+	filtered := internal.ListResources{}
+	for _, r := range allResources {
+		filtered = append(filtered, r.WithoutKeys(projectConfig.IsKeyExcluded))
 	}
 
-	fmt.Printf("\nSearch for *possible* unused keys in all resources...\n")
-	count := 0
-	for key := range keys {
-		isBeingUsed, err := internal.IsKeyBeingUsed(key)
-		if err != nil {
-			fmt.Println(err)
+	return filtered
+}
+
+// Strings that are not referenced in the source tree of the current directory
+func checkUnusedKeys(allResources internal.ListResources) []internal.Finding {
+	sources := usageSources
+	if len(sources) == 0 {
+		sources = projectConfig.EnabledUsageSources()
+	}
+
+	isUsed, err := keyUsage(sources, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error searching for unused keys: %v\n", err)
+		return nil
+	}
+
+	return allResources.UnusedFindings(isUsed)
+}
+
+// Check if a string is referenced by the given sources in the source tree of
+// the current directory. Keys kept by the project configuration or matching
+// one of keep are always used.
+func keyUsage(sources []string, keep []string) (func(key string) bool, error) {
+	index, err := internal.BuildUsageIndex(".", sources)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(key string) bool {
+		return projectConfig.IsKeyKept(key) || internal.MatchesKeyPattern(keep, key) || index.IsUsed(internal.UsageString, key)
+	}, nil
+}
+
+// Enabled usage sources that reference Android resources. Compose
+// Multiplatform resources are not Android resources, so commands that change
+// Android resources do not follow their references.
+func androidUsageSources() []string {
+	return slices.DeleteFunc(projectConfig.EnabledUsageSources(), func(source string) bool {
+		return source == internal.UsageSourceComposeResources
+	})
+}
+
+// Print the findings in the selected format. Fixed is nil when there is no baseline.
+func printFindings(w io.Writer, findings []internal.Finding, fixed []internal.BaselineEntry) error {
+	switch checkFormat {
+	case FormatJSON:
+		return writeJSON(w, struct {
+			Findings []internal.Finding       `json:"findings"`
+			Fixed    []internal.BaselineEntry `json:"fixed,omitempty"`
+		}{Findings: findings, Fixed: fixed})
+	case FormatSarif:
+		report := internal.NewSarifReport(findings, ruleSeverity)
+		if fixed != nil {
+			report = report.WithBaseline(fixed)
+		}
+		return writeJSON(w, report)
+	}
+
+	printTextFindings(w, findings)
+
+	if len(fixed) > 0 {
+		fmt.Fprintf(w, "\n%v findings of the baseline are fixed, update it with --write-baseline:\n", len(fixed))
+		for _, e := range fixed {
+			fmt.Fprintf(w, "\tFIXED: %v: %v\n", e.File, e.Message)
+		}
+	}
+
+	return nil
+}
+
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(value)
+}
+
+func printTextFindings(w io.Writer, findings []internal.Finding) {
+	for _, rule := range internal.CheckRules() {
+		if !isRuleEnabled(rule) {
 			continue
 		}
 
-		if !isBeingUsed {
-			fmt.Printf("\tString <%v> appears to be unused\n", fmt.Sprintf("R.string.%v", key))
+		fmt.Fprintln(w, ruleTitles[rule])
+
+		count := 0
+		for _, f := range findings {
+			if f.RuleID != rule {
+				continue
+			}
+
+			fmt.Fprintf(w, "\t%v: %v: %v\n", strings.ToUpper(f.Severity), f.Location(), f.Message)
 			count++
 		}
+
+		fmt.Fprintf(w, "Found %v %v findings\n\n", count, rule)
 	}
-	fmt.Printf("Found %v possible unused keys\n\n", count)
+
+	counts := map[string]int{}
+	for _, f := range findings {
+		counts[f.Severity]++
+	}
+
+	fmt.Fprintf(w, "%v errors, %v warnings, %v infos\n", counts[internal.SeverityError], counts[internal.SeverityWarning], counts[internal.SeverityInfo])
 }
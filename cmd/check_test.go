@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
 	"testing"
 
+	"polyglot/cmd/internal"
+
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,3 +19,112 @@ func TestCheckCmd_non_android_project_directory(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "current directory is not an android project", err.Error())
 }
+
+// Create an android project with unsorted files and a placeholder mismatch
+func writeCheckProject(t *testing.T) {
+	writeProject(t, map[string]string{
+		"build.gradle":              "",
+		appStringsPath("values"):    "<resources>\n    <string name=\"b\">B %1$s</string>\n    <string name=\"a\">A</string>\n</resources>\n",
+		appStringsPath("values-pt"): "<resources>\n    <string name=\"b\">B %1$d</string>\n    <string name=\"a\">A</string>\n</resources>\n",
+	})
+}
+
+func TestCheckCmd_json_format(t *testing.T) {
+	writeCheckProject(t)
+
+	checkFormat = FormatJSON
+	checkRules = []string{internal.RuleUnsorted, internal.RulePlaceholderMismatch}
+	defer func() {
+		checkFormat = FormatText
+		checkRules = []string{}
+	}()
+
+	var output bytes.Buffer
+	root := &cobra.Command{Use: "check", RunE: checkCmd.RunE}
+	root.SetOut(&output)
+
+	err := root.Execute()
+	assert.Error(t, err)
+	assert.Equal(t, "found 1 findings with severity error or higher", err.Error())
+
+	var report struct {
+		Findings []internal.Finding `json:"findings"`
+	}
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &report))
+
+	assert.Equal(t, []internal.Finding{
+		{RuleID: internal.RuleUnsorted, Severity: internal.SeverityWarning, File: "app/src/main/res/values-pt/strings.xml", Line: 3, Key: "a", Locale: "pt", Message: "File is not sorted by key, <a> is out of order"},
+		{RuleID: internal.RuleUnsorted, Severity: internal.SeverityWarning, File: "app/src/main/res/values/strings.xml", Line: 3, Key: "a", Locale: "en", Message: "File is not sorted by key, <a> is out of order"},
+		{RuleID: internal.RulePlaceholderMismatch, Severity: internal.SeverityError, File: "app/src/main/res/values-pt/strings.xml", Line: 2, Key: "b", Locale: "pt", Message: "<b> wrong type %1$d (expected %1$s)"},
+	}, report.Findings)
+}
+
+func TestCheckCmd_unparsable_file(t *testing.T) {
+	writeProject(t, map[string]string{
+		"build.gradle":              "",
+		appStringsPath("values"):    "<resources>\n    <string name=\"a\">A</string>\n</resources>\n",
+		appStringsPath("values-pt"): "<resources>\n    <string name=\"a\">A</strin>\n</resources>\n",
+	})
+
+	failOn = internal.SeverityError
+	root := &cobra.Command{Use: "check", RunE: checkCmd.RunE}
+	root.SetOut(&bytes.Buffer{})
+
+	err := root.Execute()
+	assert.Error(t, err)
+	assert.Equal(t, "could not parse 1 resource files: app/src/main/res/values-pt/strings.xml", err.Error())
+}
+
+func TestCheckCmd_fail_on(t *testing.T) {
+	writeCheckProject(t)
+
+	checkRules = []string{internal.RuleUnsorted}
+	defer func() {
+		checkRules = []string{}
+		failOn = internal.SeverityError
+	}()
+
+	root := &cobra.Command{Use: "check", RunE: checkCmd.RunE}
+	root.SetOut(&bytes.Buffer{})
+
+	failOn = internal.SeverityError
+	assert.NoError(t, root.Execute())
+
+	failOn = internal.SeverityWarning
+	err := root.Execute()
+	assert.Error(t, err)
+	assert.Equal(t, "found 2 findings with severity warning or higher", err.Error())
+
+	failOn = "fatal"
+	err = root.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown severity")
+}
+
+func TestCheckCmd_baseline(t *testing.T) {
+	writeCheckProject(t)
+
+	checkRules = []string{internal.RuleUnsorted, internal.RulePlaceholderMismatch}
+	defer func() {
+		checkRules = []string{}
+		writeBaseline = false
+		baselinePath = ""
+	}()
+
+	root := &cobra.Command{Use: "check", RunE: checkCmd.RunE}
+	root.SetOut(&bytes.Buffer{})
+
+	assert.Error(t, root.Execute())
+
+	writeBaseline = true
+	assert.NoError(t, root.Execute())
+	assert.FileExists(t, internal.DefaultBaselineFileName)
+
+	writeBaseline = false
+	assert.NoError(t, root.Execute())
+
+	baselinePath = "missing-baseline.json"
+	err := root.Execute()
+	assert.Error(t, err)
+	assert.True(t, os.IsNotExist(err))
+}
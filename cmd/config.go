@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// Configuration of the project, loaded by every command that reads it
+var projectConfig internal.Config
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the project configuration file " + internal.ConfigFileName,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the project configuration file " + internal.ConfigFileName,
+	RunE:  runConfigValidateCmd,
+}
+
+func runConfigValidateCmd(cmd *cobra.Command, args []string) error {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	path, ok := internal.FindConfigFile(currentDir)
+	if !ok {
+		return fmt.Errorf("no %v found in the current directory or its parents", internal.ConfigFileName)
+	}
+
+	_, err = internal.ReadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Configuration %v is valid\n", path)
+
+	return nil
+}
+
+// Load the project configuration and apply the settings shared by every command
+func loadProjectConfig() error {
+	config, err := internal.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	projectConfig = config
+	internal.ExcludedModuleDirectories = config.ExcludedModuleDirectories()
+
+	return nil
+}
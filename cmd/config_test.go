@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidateCmd(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	oldDir, _ := os.Getwd()
+	defer os.Chdir(oldDir)
+	os.Chdir(tmpDir)
+
+	root := &cobra.Command{Use: "validate", RunE: configValidateCmd.RunE}
+
+	err := root.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no .polyglot.yaml found")
+
+	os.WriteFile(filepath.Join(tmpDir, internal.ConfigFileName), []byte("sort: value\n"), 0o644)
+	err = root.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `sort: unknown policy "value"`)
+
+	os.WriteFile(filepath.Join(tmpDir, internal.ConfigFileName), []byte("provider: deepl\nsort: none\n"), 0o644)
+	err = root.Execute()
+	assert.NoError(t, err)
+}
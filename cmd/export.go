@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// Formats translations can be exported to
+const (
+	exportFormatXliff     = "xliff"
+	exportFormatCSV       = "csv"
+	exportFormatPO        = "po"
+	exportFormatStrings   = "strings"
+	exportFormatXCStrings = "xcstrings"
+)
+
+func exportFormats() []string {
+	return []string{exportFormatXliff, exportFormatCSV, exportFormatPO, exportFormatStrings, exportFormatXCStrings}
+}
+
+var (
+	exportFormat       string
+	exportXliffVersion string
+	exportOutput       string
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", exportFormatXliff, "Format of the exported files: "+strings.Join(exportFormats(), ", "))
+	exportCmd.Flags().StringVar(&exportXliffVersion, "xliff-version", internal.XliffVersion12, "Version of the XLIFF files: "+strings.Join(internal.XliffVersions(), " or "))
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "translations", "Directory the exported files are written to")
+	addResDirectoryFlags(exportCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the translations of a resource directory to files for translation agencies, CAT tools and iOS apps",
+	RunE:  runExportCmd,
+}
+
+func runExportCmd(cmd *cobra.Command, args []string) error {
+	err := internal.BlockIfNotAndroidProject()
+	if err != nil {
+		return err
+	}
+
+	err = loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(exportFormats(), exportFormat) {
+		return fmt.Errorf("unknown format %q, available formats: %v", exportFormat, strings.Join(exportFormats(), ", "))
+	}
+	if !slices.Contains(internal.XliffVersions(), exportXliffVersion) {
+		return fmt.Errorf("unknown XLIFF version %q, available versions: %v", exportXliffVersion, strings.Join(internal.XliffVersions(), ", "))
+	}
+
+	resDirectory, err := resDirectoryFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	translations, err := internal.SelectResDirectoryAndReturnTranslations(resDirectory)
+	if err != nil {
+		return err
+	}
+
+	defaultIndex := slices.IndexFunc(translations, internal.Translation.IsDefault)
+	if defaultIndex == -1 {
+		return fmt.Errorf("no default values/strings.xml found in the resource directory")
+	}
+
+	source, err := internal.GetResourcesFromPathXML(translations[defaultIndex].Path)
+	if err != nil {
+		return err
+	}
+	source = source.WithoutKeys(projectConfig.IsKeyExcluded)
+
+	targets := internal.ListResources{}
+	for _, t := range translations {
+		if t.IsDefault() || !projectConfig.IsTargetLocale(t) {
+			continue
+		}
+
+		target, err := internal.GetResourcesFromPathXML(t.Path)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target)
+	}
+
+	if len(targets) == 0 {
+		return fmt.Errorf("no target locales found in the resource directory")
+	}
+
+	changes := internal.NewFileChanges()
+
+	var summaries []string
+	switch exportFormat {
+	case exportFormatXliff:
+		summaries, err = exportXliff(changes, source, targets)
+	case exportFormatCSV:
+		summaries, err = exportCSV(changes, source, targets)
+	case exportFormatPO:
+		summaries, err = exportPO(changes, source, targets)
+	case exportFormatStrings:
+		summaries, err = exportStrings(changes, source, targets)
+	case exportFormatXCStrings:
+		summaries, err = exportXCStrings(changes, source, targets)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = changes.Write()
+	if err != nil {
+		return err
+	}
+
+	for _, summary := range summaries {
+		fmt.Fprintln(cmd.OutOrStdout(), summary)
+	}
+
+	return nil
+}
+
+// Stage an XLIFF file per target locale, with notes from the comments of the
+// default locale
+func exportXliff(changes *internal.FileChanges, source internal.Resources, targets internal.ListResources) ([]string, error) {
+	notes, err := internal.ResourceComments(source.Translation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := []string{}
+	for _, target := range targets {
+		t := target.Translation
+
+		units := internal.TextUnits(source, target, notes)
+		output, err := internal.RenderXliff(exportXliffVersion, internal.FindingPath(t.Path), projectConfig.SourceTranslation(), t, units)
+		if err != nil {
+			return nil, err
+		}
+
+		path := filepath.Join(exportOutput, t.LanguageTag()+".xlf")
+		err = changes.Set(path, output)
+		if err != nil {
+			return nil, err
+		}
+
+		untranslated := 0
+		for _, u := range units {
+			if !u.HasTarget() {
+				untranslated++
+			}
+		}
+		summaries = append(summaries, fmt.Sprintf("Exported %v: %v units, %v without translation", path, len(units), untranslated))
+	}
+
+	return summaries, nil
+}
+
+// Stage the translation matrix of the default and the target locales
+func exportCSV(changes *internal.FileChanges, source internal.Resources, targets internal.ListResources) ([]string, error) {
+	output, err := append(internal.ListResources{source}, targets...).RenderMatrixCSV()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(exportOutput, "strings.csv")
+	err = changes.Set(path, output)
+	if err != nil {
+		return nil, err
+	}
+
+	units := internal.TextUnits(source, source, nil)
+	return []string{fmt.Sprintf("Exported %v: %v units in %v locales", path, len(units), len(targets)+1)}, nil
+}
+
+// Stage the POT template of the default locale and a PO catalog per target
+// locale, with comments of the default locale for translators
+func exportPO(changes *internal.FileChanges, source internal.Resources, targets internal.ListResources) ([]string, error) {
+	notes, err := internal.ResourceComments(source.Translation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(exportOutput, "strings.pot")
+	err = changes.Set(path, internal.RenderPOT(source, notes))
+	if err != nil {
+		return nil, err
+	}
+	summaries := []string{fmt.Sprintf("Exported %v", path)}
+
+	for _, target := range targets {
+		t := target.Translation
+
+		path := filepath.Join(exportOutput, internal.POLanguage(t)+".po")
+		err = changes.Set(path, internal.RenderPO(source, target, notes, t))
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, fmt.Sprintf("Exported %v", path))
+	}
+
+	return summaries, nil
+}
+
+// Stage a Localizable.strings per locale of an iOS bundle, the default locale
+// in the directory of the source locale
+func exportStrings(changes *internal.FileChanges, source internal.Resources, targets internal.ListResources) ([]string, error) {
+	notes, err := internal.ResourceComments(source.Translation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	locales := []internal.Translation{projectConfig.SourceTranslation()}
+	resources := internal.ListResources{source}
+	for _, target := range targets {
+		locales = append(locales, target.Translation)
+		resources = append(resources, target)
+	}
+
+	// Plurals are the ones of the default locale, the same for every file
+	summaries := []string{}
+	var output []byte
+	var plurals []string
+	for i, t := range locales {
+		output, plurals = internal.RenderStrings(source, resources[i], notes)
+
+		path := filepath.Join(exportOutput, internal.LprojDirectory(t), "Localizable.strings")
+		err = changes.Set(path, output)
+		if err != nil {
+			return nil, err
+		}
+
+		units, untranslated := 0, 0
+		for _, u := range internal.TextUnits(source, resources[i], nil) {
+			if u.Kind == internal.KindPlurals {
+				continue
+			}
+			units++
+			if !u.HasTarget() {
+				untranslated++
+			}
+		}
+		summaries = append(summaries, fmt.Sprintf("Exported %v: %v units, %v without translation", path, units, untranslated))
+	}
+
+	if len(plurals) > 0 {
+		summaries = append(summaries, fmt.Sprintf("Left out %v plurals, .strings files cannot hold them, export with --format=xcstrings instead: %v", len(plurals), strings.Join(plurals, ", ")))
+	}
+
+	return summaries, nil
+}
+
+// Stage a String Catalog with the default locale, in the source locale, and
+// the target locales
+func exportXCStrings(changes *internal.FileChanges, source internal.Resources, targets internal.ListResources) ([]string, error) {
+	notes, err := internal.ResourceComments(source.Translation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := internal.RenderXCStrings(source, projectConfig.SourceTranslation(), targets, notes)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(exportOutput, "Localizable.xcstrings")
+	err = changes.Set(path, output)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{fmt.Sprintf("Exported %v: %v locales", path, len(targets)+1)}, nil
+}
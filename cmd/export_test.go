@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCmd_non_android_project_directory(t *testing.T) {
+	root := &cobra.Command{Use: "export", RunE: exportCmd.RunE}
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, "current directory is not an android project", err.Error())
+}
+
+func writeExportProject(t *testing.T) {
+	writeProject(t, map[string]string{
+		"build.gradle":                  "",
+		appStringsPath("values"):        "<resources>\n    <!-- Name of the club -->\n    <string name=\"club\">Palmeiras</string>\n    <string name=\"id\" translatable=\"false\">SEP</string>\n    <string name=\"stadium\">Allianz Parque</string>\n</resources>\n",
+		appStringsPath("values-pt-rBR"): "<resources>\n    <string name=\"club\">Palmeiras</string>\n</resources>\n",
+		appStringsPath("values-es"):     "<resources>\n</resources>\n",
+	})
+}
+
+func newExportCmd() *cobra.Command {
+	root := &cobra.Command{Use: "export", RunE: exportCmd.RunE}
+	root.Flags().String("res", "", "")
+	root.Flags().String("module", "", "")
+
+	return root
+}
+
+func resetExportFlags() {
+	exportFormat = exportFormatXliff
+	exportXliffVersion = "1.2"
+	exportOutput = "translations"
+}
+
+func TestExportCmd_xliff(t *testing.T) {
+	writeExportProject(t)
+	defer resetExportFlags()
+
+	var output bytes.Buffer
+	root := newExportCmd()
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+	assert.Contains(t, output.String(), "Exported translations/pt-BR.xlf: 2 units, 1 without translation")
+	assert.Contains(t, output.String(), "Exported translations/es.xlf: 2 units, 2 without translation")
+
+	got, err := os.ReadFile(filepath.Join("translations", "pt-BR.xlf"))
+	assert.NoError(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="app/src/main/res/values-pt-rBR/strings.xml" source-language="en" target-language="pt-BR" datatype="xml">
+    <body>
+      <trans-unit id="club" resname="club">
+        <source>Palmeiras</source>
+        <target state="translated">Palmeiras</target>
+        <note>Name of the club</note>
+      </trans-unit>
+      <trans-unit id="stadium" resname="stadium">
+        <source>Allianz Parque</source>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`
+	assert.Equal(t, expected, string(got))
+}
+
+func TestExportCmd_xliff_2_0(t *testing.T) {
+	writeExportProject(t)
+	defer resetExportFlags()
+
+	exportXliffVersion = "2.0"
+	exportOutput = "out"
+
+	root := newExportCmd()
+	root.SetOut(&bytes.Buffer{})
+
+	assert.NoError(t, root.Execute())
+
+	got, err := os.ReadFile(filepath.Join("out", "es.xlf"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), `<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en" trgLang="es">`)
+	assert.Contains(t, string(got), `<segment state="initial">`)
+}
+
+func TestExportCmd_unknown_format(t *testing.T) {
+	writeExportProject(t)
+	defer resetExportFlags()
+
+	exportFormat = "docx"
+
+	err := newExportCmd().Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, `unknown format "docx", available formats: xliff, csv, po, strings, xcstrings`, err.Error())
+}
+
+func TestExportCmd_csv(t *testing.T) {
+	writeExportProject(t)
+	defer resetExportFlags()
+
+	exportFormat = exportFormatCSV
+
+	var output bytes.Buffer
+	root := newExportCmd()
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+	assert.Contains(t, output.String(), "Exported translations/strings.csv: 2 units in 3 locales")
+
+	got, err := os.ReadFile(filepath.Join("translations", "strings.csv"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), "key,values,values-")
+	assert.Contains(t, string(got), "\nstadium,Allianz Parque,")
+	assert.NotContains(t, string(got), "SEP")
+}
+
+func TestExportCmd_po(t *testing.T) {
+	writeExportProject(t)
+	defer resetExportFlags()
+
+	exportFormat = exportFormatPO
+
+	var output bytes.Buffer
+	root := newExportCmd()
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+	assert.Contains(t, output.String(), "Exported translations/strings.pot")
+	assert.Contains(t, output.String(), "Exported translations/pt_BR.po")
+	assert.Contains(t, output.String(), "Exported translations/es.po")
+
+	got, err := os.ReadFile(filepath.Join("translations", "pt_BR.po"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), "\"Language: pt_BR\\n\"\n")
+	assert.Contains(t, string(got), "#. Name of the club\nmsgctxt \"club\"\nmsgid \"Palmeiras\"\nmsgstr \"Palmeiras\"\n")
+	assert.Contains(t, string(got), "msgctxt \"stadium\"\nmsgid \"Allianz Parque\"\nmsgstr \"\"\n")
+	assert.NotContains(t, string(got), "SEP")
+}
+
+func TestExportCmd_strings(t *testing.T) {
+	writeExportProject(t)
+	defer resetExportFlags()
+
+	exportFormat = exportFormatStrings
+
+	var output bytes.Buffer
+	root := newExportCmd()
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+	assert.Contains(t, output.String(), "Exported translations/en.lproj/Localizable.strings: 2 units, 0 without translation")
+	assert.Contains(t, output.String(), "Exported translations/pt-BR.lproj/Localizable.strings: 2 units, 1 without translation")
+
+	got, err := os.ReadFile(filepath.Join("translations", "pt-BR.lproj", "Localizable.strings"))
+	assert.NoError(t, err)
+	assert.Equal(t, "/* Exported by polyglot from app/src/main/res/values-pt-rBR/strings.xml */\n\n/* Name of the club */\n\"club\" = \"Palmeiras\";\n", string(got))
+
+	_, err = os.Stat(filepath.Join("translations", "es.lproj", "Localizable.strings"))
+	assert.NoError(t, err)
+}
+
+func TestExportCmd_xcstrings(t *testing.T) {
+	writeExportProject(t)
+	defer resetExportFlags()
+
+	exportFormat = exportFormatXCStrings
+
+	var output bytes.Buffer
+	root := newExportCmd()
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+	assert.Contains(t, output.String(), "Exported translations/Localizable.xcstrings: 3 locales")
+
+	got, err := os.ReadFile(filepath.Join("translations", "Localizable.xcstrings"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), `"sourceLanguage": "en"`)
+	assert.Contains(t, string(got), `"pt-BR": {`)
+	assert.NotContains(t, string(got), "SEP")
+}
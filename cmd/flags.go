@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags shared by every command that calls a translation provider
+func addTranslatorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("provider", "p", "", "Translation provider to use: google, deepl or libretranslate (if not set it will use the POLYGLOT_TRANSLATION_PROVIDER environment variable, defaults to google)")
+	cmd.Flags().StringP("googleApiKey", "g", "", "Google Translate API Key (if not set it will use the GOOGLE_TRANSLATE_KEY environment variable)")
+	cmd.Flags().String("deeplAuthKey", "", "DeepL API authentication key (if not set it will use the DEEPL_AUTH_KEY environment variable)")
+	cmd.Flags().String("formality", "", "Formality of DeepL translations: default, more, less, prefer_more or prefer_less")
+	cmd.Flags().String("libretranslateUrl", "", "Base URL of a LibreTranslate compatible server (if not set it will use the LIBRETRANSLATE_URL environment variable)")
+	cmd.Flags().String("libretranslateApiKey", "", "LibreTranslate API key, if the server requires one (if not set it will use the LIBRETRANSLATE_API_KEY environment variable)")
+}
+
+func newTranslatorFromFlags(cmd *cobra.Command) (internal.Translator, error) {
+	options := internal.TranslatorOptions{
+		GoogleApiKey:   cmd.Flag("googleApiKey").Value.String(),
+		DeepLAuthKey:   cmd.Flag("deeplAuthKey").Value.String(),
+		DeepLFormality: cmd.Flag("formality").Value.String(),
+
+		LibreTranslateURL:    cmd.Flag("libretranslateUrl").Value.String(),
+		LibreTranslateApiKey: cmd.Flag("libretranslateApiKey").Value.String(),
+
+		DefaultProvider: projectConfig.Provider,
+	}
+
+	// Only the key of the selected provider is used, so the configured
+	// variable can fill all of them when the flags are not set
+	if projectConfig.ApiKeyEnv != "" {
+		key := os.Getenv(projectConfig.ApiKeyEnv)
+		options.GoogleApiKey = cmp.Or(options.GoogleApiKey, key)
+		options.DeepLAuthKey = cmp.Or(options.DeepLAuthKey, key)
+		options.LibreTranslateApiKey = cmp.Or(options.LibreTranslateApiKey, key)
+	}
+
+	return internal.NewTranslator(cmd.Flag("provider").Value.String(), options)
+}
+
+// Flags of every command that works on a single resource directory
+func addResDirectoryFlags(cmd *cobra.Command) {
+	cmd.Flags().String("res", "", "Path of the resource directory to use, skipping the interactive selection")
+	cmd.Flags().String("module", "", "Gradle module whose resource directory will be used (e.g. :feature:login), skipping the interactive selection")
+}
+
+// Resource directory selected by --res or --module, empty if none was given
+func resDirectoryFromFlags(cmd *cobra.Command) (string, error) {
+	res, _ := cmd.Flags().GetString("res")
+	module, _ := cmd.Flags().GetString("module")
+
+	if res != "" && module != "" {
+		return "", fmt.Errorf("use --res or --module, not both")
+	}
+
+	if module != "" {
+		return internal.FindModuleResourcesDirectoryPath(module)
+	}
+
+	return res, nil
+}
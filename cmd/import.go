@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+)
+
+var dryRunI bool
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().BoolVar(&dryRunI, "dry-run", false, "Print the diff of the files instead of changing them")
+	addResDirectoryFlags(importCmd)
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import file...",
+	Short: "Import the translated units of XLIFF files and PO catalogs, or the edited cells of a translation matrix CSV, into the strings.xml files",
+	RunE:  runImportCmd,
+}
+
+func runImportCmd(cmd *cobra.Command, args []string) error {
+	err := internal.BlockIfNotAndroidProject()
+	if err != nil {
+		return err
+	}
+
+	err = loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("you need to pass the files to import, e.g. polyglot import translations/pt-BR.xlf")
+	}
+
+	resDirectory, err := resDirectoryFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	translations, err := internal.SelectResDirectoryAndReturnTranslations(resDirectory)
+	if err != nil {
+		return err
+	}
+
+	i, err := newUnitImport(translations)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range args {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".xlf", ".xliff":
+			err = i.importXliff(path, content)
+		case ".csv":
+			err = i.importCSV(path, content)
+		case ".po":
+			err = i.importPO(path, content)
+		default:
+			err = fmt.Errorf("unknown format of %v, import .xlf, .xliff, .csv or .po files", path)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return i.finish(cmd, dryRunI)
+}
+
+// Import of text units into the strings.xml files of a resource directory,
+// staged until every file is read so a broken file changes nothing
+type unitImport struct {
+	translations []internal.Translation
+	source       internal.Resources
+	known        map[string]bool
+	resources    map[string]internal.Resources
+	changes      *internal.FileChanges
+	summaries    []string
+	problems     []string
+}
+
+func newUnitImport(translations []internal.Translation) (*unitImport, error) {
+	defaultIndex := slices.IndexFunc(translations, internal.Translation.IsDefault)
+	if defaultIndex == -1 {
+		return nil, fmt.Errorf("no default values/strings.xml found in the resource directory")
+	}
+
+	source, err := internal.GetResourcesFromPathXML(translations[defaultIndex].Path)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &unitImport{
+		translations: translations,
+		source:       source,
+		known:        map[string]bool{},
+		resources:    map[string]internal.Resources{},
+		changes:      internal.NewFileChanges(),
+	}
+	for _, u := range internal.TextUnits(source, internal.Resources{}, nil) {
+		i.known[u.ID] = true
+		if u.Kind == internal.KindPlurals {
+			i.known[internal.PluralsReportKey(u.Key)] = true
+		}
+	}
+
+	return i, nil
+}
+
+// Check if the unit is of a translatable resource of the default locale. Every
+// quantity of a known plurals is known, as each language has its own.
+func (i *unitImport) isKnown(u internal.TextUnit) bool {
+	return i.known[u.ID] || u.Kind == internal.KindPlurals && i.known[internal.PluralsReportKey(u.Key)]
+}
+
+// Path of the strings.xml of the translation in the resource directory, which
+// may not exist yet
+func (i *unitImport) pathOf(t internal.Translation) string {
+	if t.IsDefault() {
+		return t.Path
+	}
+
+	for _, existing := range i.translations {
+		if !existing.IsDefault() && existing.Qualifier() == t.Qualifier() {
+			return existing.Path
+		}
+	}
+
+	return filepath.Join(i.translations[0].ResourceDirectory(), "values-"+t.Qualifier(), "strings.xml")
+}
+
+// Stage the units of the file in path that are ready to be imported into the
+// strings.xml of the translation. Units of keys that are not translatable
+// resources of the default locale are reported.
+func (i *unitImport) apply(path string, t internal.Translation, units []internal.TextUnit, isReady func(state string) bool) (string, error) {
+	target := i.pathOf(t)
+
+	r, err := i.current(target)
+	if err != nil {
+		return "", err
+	}
+
+	imported := []internal.TextUnit{}
+	skipped := 0
+	for _, u := range units {
+		switch {
+		case !i.isKnown(u):
+			i.problems = append(i.problems, fmt.Sprintf("%v: unknown key %v", path, u.ID))
+		case !isReady(u.State) || !u.HasTarget():
+			skipped++
+		default:
+			imported = append(imported, u)
+		}
+	}
+
+	r, incomplete := r.WithTextUnits(i.source, imported, projectConfig.SortsByKey())
+	for _, key := range incomplete {
+		i.problems = append(i.problems, fmt.Sprintf("%v: string-array <%v> skipped, some of its items are not translated", path, key))
+	}
+	imported = slices.DeleteFunc(imported, func(u internal.TextUnit) bool {
+		return u.Kind == internal.KindStringArray && slices.Contains(incomplete, u.Key)
+	})
+	i.resources[target] = r
+
+	var output []byte
+	if _, err := os.Stat(target); err == nil {
+		rendered, err := r.RenderXML(target)
+		if err != nil {
+			return "", err
+		}
+		output = rendered
+	} else {
+		output = r.RenderNewXML()
+	}
+
+	err = i.changes.Set(target, output)
+	if err != nil {
+		return "", err
+	}
+
+	summary := fmt.Sprintf("Imported %v units of %v into %v", len(imported), path, internal.FindingPath(target))
+	if skipped > 0 {
+		summary += fmt.Sprintf(", skipped %v units that are not translated", skipped)
+	}
+
+	return summary, nil
+}
+
+// Resources of the strings.xml in path with the units imported so far, empty
+// when the file does not exist yet
+func (i *unitImport) current(path string) (internal.Resources, error) {
+	if r, ok := i.resources[path]; ok {
+		return r, nil
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return internal.Resources{}, nil
+	}
+
+	return internal.GetResourcesFromPathXML(path)
+}
+
+// Stage the units of an XLIFF file whose target is translated
+func (i *unitImport) importXliff(path string, content []byte) error {
+	document, err := internal.ParseXliff(content)
+	if err != nil {
+		return fmt.Errorf("%v: %v", path, err)
+	}
+
+	t, err := internal.TranslationFromLanguageTag(document.TargetLanguage)
+	if err != nil {
+		return fmt.Errorf("%v: %v", path, err)
+	}
+
+	summary, err := i.apply(path, t, document.Units, internal.IsXliffStateTranslated)
+	if err != nil {
+		return err
+	}
+	i.summaries = append(i.summaries, summary)
+
+	return nil
+}
+
+// Stage the units of a PO catalog that are translated and not fuzzy
+func (i *unitImport) importPO(path string, content []byte) error {
+	document, err := internal.ParsePO(content)
+	if err != nil {
+		return fmt.Errorf("%v: %v", path, err)
+	}
+
+	t, err := internal.TranslationFromLanguageTag(document.Language)
+	if err != nil {
+		return fmt.Errorf("%v: %v", path, err)
+	}
+
+	summary, err := i.apply(path, t, document.Units, func(state string) bool { return state != internal.POStateFuzzy })
+	if err != nil {
+		return err
+	}
+	i.summaries = append(i.summaries, summary)
+
+	return nil
+}
+
+// Stage the cells of a translation matrix edited since it was exported. Cells
+// whose text also changed in the file are reported as conflicts and the file
+// is kept.
+func (i *unitImport) importCSV(path string, content []byte) error {
+	columns, rows, err := internal.ParseMatrixCSV(content)
+	if err != nil {
+		return fmt.Errorf("%v: %v", path, err)
+	}
+
+	for _, row := range rows {
+		if !i.known[row.ID] {
+			i.problems = append(i.problems, fmt.Sprintf("%v: unknown key %v", path, row.ID))
+		}
+	}
+
+	for _, column := range columns {
+		t, err := i.columnTranslation(column)
+		if err != nil {
+			return fmt.Errorf("%v: %v", path, err)
+		}
+
+		r, err := i.current(i.pathOf(t))
+		if err != nil {
+			return err
+		}
+
+		current := map[string]string{}
+		for _, u := range internal.TextUnits(i.source, r, nil) {
+			current[u.ID] = internal.ResourceText(u.Target)
+		}
+
+		units := []internal.TextUnit{}
+		for _, row := range rows {
+			if !i.known[row.ID] {
+				continue
+			}
+
+			switch row.CellChange(column, current[row.ID]) {
+			case internal.CellEdited:
+				u, _ := internal.ParseUnitID(row.ID)
+				u.Target = internal.ResourceValue(row.Texts[column])
+				u.State = internal.CellEdited
+				units = append(units, u)
+			case internal.CellConflict:
+				i.problems = append(i.problems, fmt.Sprintf("%v: %v of %v changed in the file since the export, keeping %q", path, column, row.ID, current[row.ID]))
+			}
+		}
+
+		if len(units) == 0 {
+			continue
+		}
+
+		summary, err := i.apply(path, t, units, func(state string) bool { return state == internal.CellEdited })
+		if err != nil {
+			return err
+		}
+		i.summaries = append(i.summaries, summary)
+	}
+
+	return nil
+}
+
+// Translation of a column of the translation matrix, e.g. values-pt-rBR
+func (i *unitImport) columnTranslation(column string) (internal.Translation, error) {
+	if column == "values" {
+		return i.translations[slices.IndexFunc(i.translations, internal.Translation.IsDefault)], nil
+	}
+
+	return internal.TranslationFromQualifier(strings.TrimPrefix(column, "values-"))
+}
+
+// Print the diff of the staged changes on dry runs, otherwise write them, and
+// report the units that could not be imported
+func (i *unitImport) finish(cmd *cobra.Command, dryRun bool) error {
+	if dryRun {
+		diff, err := i.changes.Diff()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), diff)
+		fmt.Fprintf(os.Stderr, "%v files would be changed, run without --dry-run to change them\n", len(i.changes.Paths()))
+	} else {
+		err := i.changes.Write()
+		if err != nil {
+			return err
+		}
+
+		for _, summary := range i.summaries {
+			fmt.Fprintln(cmd.OutOrStdout(), summary)
+		}
+		if len(i.summaries) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No edited cells to import")
+		}
+	}
+
+	if len(i.problems) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nFound %v problems, the units were not imported:\n", len(i.problems))
+		for _, problem := range i.problems {
+			fmt.Fprintf(cmd.OutOrStdout(), "\t%v\n", problem)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportCmd_non_android_project_directory(t *testing.T) {
+	root := &cobra.Command{Use: "import", RunE: importCmd.RunE}
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, "current directory is not an android project", err.Error())
+}
+
+func writeImportProject(t *testing.T) {
+	writeProject(t, map[string]string{
+		"build.gradle":                  "",
+		appStringsPath("values"):        "<resources>\n    <string name=\"club\">Palmeiras</string>\n    <string name=\"id\" translatable=\"false\">SEP</string>\n    <string name=\"stadium\">Allianz Parque</string>\n    <plurals name=\"titles\">\n        <item quantity=\"one\">%d title</item>\n        <item quantity=\"other\">%d titles</item>\n    </plurals>\n</resources>\n",
+		appStringsPath("values-pt-rBR"): "<resources>\n    <string name=\"club\">Palmeiras</string>\n</resources>\n",
+	})
+}
+
+func newImportCmd(args ...string) *cobra.Command {
+	root := &cobra.Command{Use: "import", RunE: importCmd.RunE}
+	root.Flags().String("res", "", "")
+	root.Flags().String("module", "", "")
+	root.SetArgs(args)
+
+	return root
+}
+
+const importXliff = `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="app/src/main/res/values-pt-rBR/strings.xml" source-language="en" target-language="pt-BR" datatype="xml">
+    <body>
+      <trans-unit id="stadium"><source>Allianz Parque</source><target state="translated">Arena do Palmeiras</target></trans-unit>
+      <trans-unit id="titles:one"><source>%d title</source><target state="final">%d título</target></trans-unit>
+      <trans-unit id="titles:other"><source>%d titles</source><target state="new">%d títulos</target></trans-unit>
+      <trans-unit id="coach"><source>Coach</source><target state="translated">Técnico</target></trans-unit>
+    </body>
+  </file>
+</xliff>
+`
+
+func TestImportCmd_xliff(t *testing.T) {
+	writeImportProject(t)
+	os.WriteFile("pt-BR.xlf", []byte(importXliff), 0o644)
+
+	var output bytes.Buffer
+	root := newImportCmd("pt-BR.xlf")
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile(filepath.Join("app", "src", "main", "res", "values-pt-rBR", "strings.xml"))
+	expected := "<resources>\n    <string name=\"club\">Palmeiras</string>\n    <string name=\"stadium\">Arena do Palmeiras</string>\n    <plurals name=\"titles\">\n        <item quantity=\"one\">%d título</item>\n    </plurals>\n</resources>\n"
+	assert.Equal(t, expected, string(got))
+
+	assert.Contains(t, output.String(), "Imported 2 units of pt-BR.xlf into app/src/main/res/values-pt-rBR/strings.xml, skipped 1 units that are not translated")
+	assert.Contains(t, output.String(), "pt-BR.xlf: unknown key coach")
+}
+
+func TestImportCmd_new_locale(t *testing.T) {
+	writeImportProject(t)
+	os.WriteFile("es.xlf", []byte(`<xliff version="2.0" srcLang="en" trgLang="es"><file id="f1">
+<unit id="club"><segment state="final"><source>Palmeiras</source><target>Palmeiras</target></segment></unit>
+</file></xliff>`), 0o644)
+
+	root := newImportCmd("es.xlf")
+	root.SetOut(&bytes.Buffer{})
+
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile(filepath.Join("app", "src", "main", "res", "values-es", "strings.xml"))
+	assert.Equal(t, "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n    <string name=\"club\">Palmeiras</string>\n</resources>\n", string(got))
+}
+
+func TestImportCmd_dry_run(t *testing.T) {
+	writeImportProject(t)
+	os.WriteFile("pt-BR.xlf", []byte(importXliff), 0o644)
+
+	dryRunI = true
+	defer func() { dryRunI = false }()
+
+	var output bytes.Buffer
+	root := newImportCmd("pt-BR.xlf")
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+	assert.Contains(t, output.String(), "+    <string name=\"stadium\">Arena do Palmeiras</string>")
+
+	got, _ := os.ReadFile(filepath.Join("app", "src", "main", "res", "values-pt-rBR", "strings.xml"))
+	assert.Equal(t, "<resources>\n    <string name=\"club\">Palmeiras</string>\n</resources>\n", string(got))
+}
+
+func TestImportCmd_invalid_file(t *testing.T) {
+	writeImportProject(t)
+	os.WriteFile("broken.xlf", []byte(`<xliff version="1.2"><file target-language="zh-Hant"></file></xliff>`), 0o644)
+
+	err := newImportCmd("broken.xlf").Execute()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `broken.xlf: unsupported language tag "zh-Hant"`)
+}
+
+func TestImportCmd_csv(t *testing.T) {
+	writeImportProject(t)
+
+	exportFormat = exportFormatCSV
+	defer resetExportFlags()
+
+	export := newExportCmd()
+	export.SetOut(&bytes.Buffer{})
+	assert.NoError(t, export.Execute())
+
+	path := filepath.Join("translations", "strings.csv")
+	content, _ := os.ReadFile(path)
+	content = bytes.Replace(content, []byte("\nclub,Palmeiras,Palmeiras,"), []byte("\nclub,Palmeiras,Verdão,"), 1)
+	content = bytes.Replace(content, []byte("\nstadium,Allianz Parque,,"), []byte("\nstadium,Allianz Parque,Arena,"), 1)
+	content = bytes.Replace(content, []byte("\ntitles:one,%d title,,"), []byte("\ntitles:one,%d championship,,"), 1)
+	os.WriteFile(path, content, 0o644)
+
+	// Changed by someone else after the export
+	ptPath := filepath.Join("app", "src", "main", "res", "values-pt-rBR", "strings.xml")
+	os.WriteFile(ptPath, []byte("<resources>\n    <string name=\"club\">Palestra</string>\n</resources>\n"), 0o644)
+
+	var output bytes.Buffer
+	root := newImportCmd(path)
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile(ptPath)
+	assert.Equal(t, "<resources>\n    <string name=\"club\">Palestra</string>\n    <string name=\"stadium\">Arena</string>\n</resources>\n", string(got))
+
+	got, _ = os.ReadFile(filepath.Join("app", "src", "main", "res", "values", "strings.xml"))
+	assert.Contains(t, string(got), "<item quantity=\"one\">%d championship</item>")
+
+	assert.Contains(t, output.String(), `values-pt-rBR of club changed in the file since the export, keeping "Palestra"`)
+}
+
+func TestImportCmd_po(t *testing.T) {
+	writeImportProject(t)
+	os.WriteFile("pt_BR.po", []byte(`msgid ""
+msgstr ""
+"Language: pt_BR\n"
+"Plural-Forms: nplurals=2; plural=(n > 1);\n"
+
+msgctxt "stadium"
+msgid "Allianz Parque"
+msgstr "Arena do Palmeiras"
+
+#, fuzzy
+msgctxt "club"
+msgid "Palmeiras"
+msgstr "Verdão"
+
+msgctxt "titles"
+msgid "%d title"
+msgid_plural "%d titles"
+msgstr[0] "%d título"
+msgstr[1] "%d títulos"
+`), 0o644)
+
+	var output bytes.Buffer
+	root := newImportCmd("pt_BR.po")
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile(filepath.Join("app", "src", "main", "res", "values-pt-rBR", "strings.xml"))
+	expected := "<resources>\n    <string name=\"club\">Palmeiras</string>\n    <string name=\"stadium\">Arena do Palmeiras</string>\n    <plurals name=\"titles\">\n        <item quantity=\"one\">%d título</item>\n        <item quantity=\"other\">%d títulos</item>\n    </plurals>\n</resources>\n"
+	assert.Equal(t, expected, string(got))
+
+	assert.Contains(t, output.String(), "Imported 3 units of pt_BR.po into app/src/main/res/values-pt-rBR/strings.xml, skipped 1 units that are not translated")
+}
+
+func TestImportCmd_po_russian_plurals(t *testing.T) {
+	writeImportProject(t)
+	os.WriteFile("ru.po", []byte(`msgid ""
+msgstr ""
+"Language: ru\n"
+"Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+msgctxt "titles"
+msgid "%d title"
+msgid_plural "%d titles"
+msgstr[0] "%d титул"
+msgstr[1] "%d титула"
+msgstr[2] "%d титулов"
+`), 0o644)
+
+	root := newImportCmd("ru.po")
+	root.SetOut(&bytes.Buffer{})
+
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile(filepath.Join("app", "src", "main", "res", "values-ru", "strings.xml"))
+	assert.Contains(t, string(got), "<item quantity=\"many\">%d титулов</item>")
+	assert.Contains(t, string(got), "<item quantity=\"other\">%d титулов</item>", "Android requires other in every plurals")
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strings"
 
 	"golang.org/x/text/language"
 	"golang.org/x/text/language/display"
@@ -37,6 +39,10 @@ func BlockIfNotAndroidProject() error {
 	return fmt.Errorf("current directory is not an android project")
 }
 
+// Directories of the modules whose resources are never searched, relative to
+// the project root. Set from the exclude_modules of the project configuration.
+var ExcludedModuleDirectories = []string{}
+
 func FindResourcesDirectoriesPath(root string) ([]string, error) {
 	var resDirs []string
 
@@ -45,6 +51,10 @@ func FindResourcesDirectoriesPath(root string) ([]string, error) {
 			return err
 		}
 
+		if info.IsDir() && isExcludedModuleDirectory(root, path) {
+			return filepath.SkipDir
+		}
+
 		if info.IsDir() && info.Name() == "res" {
 			if isAndroidResourceDirectory(path) {
 				resDirs = append(resDirs, path)
@@ -60,6 +70,128 @@ func FindResourcesDirectoriesPath(root string) ([]string, error) {
 	return resDirs, nil
 }
 
+// Resource directory of a Gradle module, e.g. :feature:login. The main source
+// set is used when the module has resources in more than one source set.
+func FindModuleResourcesDirectoryPath(module string) (string, error) {
+	moduleDir := ModuleDirectory(module)
+
+	if info, err := os.Stat(moduleDir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("module %v not found at %v", module, moduleDir)
+	}
+
+	resDirs, err := FindResourcesDirectoriesPath(moduleDir)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(resDirs) {
+	case 0:
+		return "", fmt.Errorf("no android resource directories found in module %v", module)
+	case 1:
+		return resDirs[0], nil
+	}
+
+	mainResDir := filepath.Join(moduleDir, "src", "main", "res")
+	if slices.Contains(resDirs, mainResDir) {
+		return mainResDir, nil
+	}
+
+	return "", fmt.Errorf("module %v has more than one resource directory, select one with --res: %v", module, strings.Join(resDirs, ", "))
+}
+
+// Directory of a Gradle module relative to the project root, :feature:login is feature/login
+func ModuleDirectory(module string) string {
+	return filepath.Join(strings.Split(strings.TrimPrefix(module, ":"), ":")...)
+}
+
+var buildFileNames = []string{"build.gradle", "build.gradle.kts"}
+
+// Gradle module of the file in path, relative to the project in the current
+// directory: the closest parent directory with a build file, e.g. :feature:login.
+// Files outside of any module belong to the root project, ":".
+func ModuleOfPath(path string) string {
+	root, err := os.Getwd()
+	if err != nil {
+		return ":"
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return ":"
+	}
+
+	for {
+		relative, err := filepath.Rel(root, dir)
+		if err != nil || relative == "." || strings.HasPrefix(relative, "..") {
+			return ":"
+		}
+
+		if _, ok := moduleBuildFile(dir); ok {
+			return ":" + strings.ReplaceAll(filepath.ToSlash(relative), "/", ":")
+		}
+
+		dir = filepath.Dir(dir)
+	}
+}
+
+func moduleBuildFile(dir string) (string, bool) {
+	for _, name := range buildFileNames {
+		p := filepath.Join(dir, name)
+		if _, err := os.Stat(p); err == nil {
+			return p, true
+		}
+	}
+
+	return "", false
+}
+
+// Check if the build file of module declares a direct dependency on the module
+// dependency, as project(":feature:login") or as the type-safe accessor
+// projects.feature.login. Transitive dependencies are not resolved.
+func ModuleDependsOn(module, dependency string) bool {
+	if module == dependency {
+		return true
+	}
+
+	buildFile, ok := moduleBuildFile(ModuleDirectory(module))
+	if !ok {
+		return false
+	}
+
+	content, err := os.ReadFile(buildFile)
+	if err != nil {
+		return false
+	}
+
+	projectRegex := regexp.MustCompile(`project\(\s*(?:path\s*[:=]\s*)?["']` + regexp.QuoteMeta(dependency) + `["']`)
+	accessorRegex := regexp.MustCompile(`\bprojects\.` + regexp.QuoteMeta(projectAccessor(dependency)) + `\b(?:[^.]|$)`)
+
+	return projectRegex.Match(content) || accessorRegex.Match(content)
+}
+
+// Type-safe project accessor of a module, :feature:user-profile is feature.userProfile
+func projectAccessor(module string) string {
+	segments := strings.Split(strings.TrimPrefix(module, ":"), ":")
+	for i, segment := range segments {
+		words := strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' })
+		for j := 1; j < len(words); j++ {
+			words[j] = strings.ToUpper(words[j][:1]) + words[j][1:]
+		}
+		segments[i] = strings.Join(words, "")
+	}
+
+	return strings.Join(segments, ".")
+}
+
+func isExcludedModuleDirectory(root, path string) bool {
+	relative, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return slices.Contains(ExcludedModuleDirectories, relative)
+}
+
 func isAndroidResourceDirectory(path string) bool {
 	if _, err := os.Stat(filepath.Join(path, "values")); !os.IsNotExist(err) {
 		return true
@@ -96,6 +228,44 @@ func GetTranslationsFromAllModules() ([]Translation, error) {
 	return translations, nil
 }
 
+// Translations of a single res directory
+type ResourceDirectory struct {
+	Path         string
+	Translations []Translation
+}
+
+// The default values/strings.xml of the directory, source of the other translations
+func (d ResourceDirectory) DefaultTranslation() (Translation, bool) {
+	for _, t := range d.Translations {
+		if t.IsDefault() {
+			return t, true
+		}
+	}
+
+	return Translation{}, false
+}
+
+// Group translations by their res directory, keeping the order they were found
+func GroupTranslationsByResourceDirectory(translations []Translation) []ResourceDirectory {
+	directories := []ResourceDirectory{}
+	indexes := map[string]int{}
+
+	for _, t := range translations {
+		path := t.ResourceDirectory()
+
+		index, ok := indexes[path]
+		if !ok {
+			index = len(directories)
+			indexes[path] = index
+			directories = append(directories, ResourceDirectory{Path: path})
+		}
+
+		directories[index].Translations = append(directories[index].Translations, t)
+	}
+
+	return directories
+}
+
 func GetTranslationsFromResourceDirectory(path string) ([]Translation, error) {
 	translations := []Translation{}
 
@@ -3,6 +3,7 @@ package internal
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 	"testing"
@@ -440,3 +441,201 @@ func TestGetTranslationsFromAllModules(t *testing.T) {
 		})
 	}
 }
+
+func TestGroupTranslationsByResourceDirectory(t *testing.T) {
+	translations := []Translation{
+		{Path: "app/src/main/res/values/strings.xml"},
+		{Path: "feature/src/main/res/values-pt/strings.xml"},
+		{Path: "app/src/main/res/values-pt/strings.xml"},
+		{Path: "feature/src/main/res/values/strings.xml"},
+	}
+
+	expected := []ResourceDirectory{
+		{
+			Path: "app/src/main/res",
+			Translations: []Translation{
+				{Path: "app/src/main/res/values/strings.xml"},
+				{Path: "app/src/main/res/values-pt/strings.xml"},
+			},
+		},
+		{
+			Path: "feature/src/main/res",
+			Translations: []Translation{
+				{Path: "feature/src/main/res/values-pt/strings.xml"},
+				{Path: "feature/src/main/res/values/strings.xml"},
+			},
+		},
+	}
+
+	got := GroupTranslationsByResourceDirectory(translations)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("GroupTranslationsByResourceDirectory() = %v, want %v", got, expected)
+	}
+
+	defaultTranslation, ok := got[1].DefaultTranslation()
+	if !ok || defaultTranslation.Path != "feature/src/main/res/values/strings.xml" {
+		t.Errorf("DefaultTranslation() = %v, %v", defaultTranslation, ok)
+	}
+
+	_, ok = ResourceDirectory{Translations: []Translation{{Path: "res/values-pt/strings.xml"}}}.DefaultTranslation()
+	if ok {
+		t.Errorf("DefaultTranslation() found a default translation where there is none")
+	}
+}
+
+func TestFindModuleResourcesDirectoryPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		dirs          []string
+		module        string
+		expected      string
+		errorContains string
+	}{
+		{
+			name:     "Nested module",
+			dirs:     []string{"feature/login/src/main/res/values"},
+			module:   ":feature:login",
+			expected: filepath.Join("feature", "login", "src", "main", "res"),
+		},
+		{
+			name:     "Module without leading colon",
+			dirs:     []string{"app/src/main/res/values"},
+			module:   "app",
+			expected: filepath.Join("app", "src", "main", "res"),
+		},
+		{
+			name:     "Main source set is preferred",
+			dirs:     []string{"app/src/debug/res/values", "app/src/main/res/values"},
+			module:   ":app",
+			expected: filepath.Join("app", "src", "main", "res"),
+		},
+		{
+			name:          "Several source sets without main",
+			dirs:          []string{"app/src/debug/res/values", "app/src/release/res/values"},
+			module:        ":app",
+			errorContains: "more than one resource directory",
+		},
+		{
+			name:          "Module without resources",
+			dirs:          []string{"app/src/main/java"},
+			module:        ":app",
+			errorContains: "no android resource directories found",
+		},
+		{
+			name:          "Unknown module",
+			dirs:          []string{},
+			module:        ":palmeiras",
+			errorContains: "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for _, dir := range tt.dirs {
+				os.MkdirAll(filepath.Join(tmpDir, dir), 0o755)
+			}
+
+			oldDir, _ := os.Getwd()
+			defer os.Chdir(oldDir)
+			if err := os.Chdir(tmpDir); err != nil {
+				t.Fatalf("failed to change directory: %v", err)
+			}
+
+			got, err := FindModuleResourcesDirectoryPath(tt.module)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("FindModuleResourcesDirectoryPath() error = %v, want %v", err, tt.errorContains)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("FindModuleResourcesDirectoryPath() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindResourcesDirectoriesPathExcludedModules(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, dir := range []string{"app/src/main/res/values", "benchmark/src/main/res/values", "feature/debug/src/main/res/values"} {
+		os.MkdirAll(filepath.Join(tmpDir, dir), 0o755)
+	}
+
+	ExcludedModuleDirectories = []string{"benchmark", filepath.Join("feature", "debug")}
+	defer func() { ExcludedModuleDirectories = []string{} }()
+
+	got, err := FindResourcesDirectoriesPath(tmpDir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{filepath.Join(tmpDir, "app", "src", "main", "res")}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("FindResourcesDirectoriesPath() = %v, want %v", got, expected)
+	}
+}
+
+func TestModuleOfPathAndDependencies(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		"build.gradle":                        "",
+		"app/build.gradle.kts":                "dependencies {\n    implementation(project(\":core\"))\n    implementation(projects.feature.userProfile)\n    implementation(projects.feature.login.api)\n}\n",
+		"core/build.gradle":                   "dependencies {\n    implementation project(path: ':design')\n}\n",
+		"feature/user-profile/build.gradle":   "",
+		"app/src/main/java/Main.kt":           "",
+		"feature/user-profile/src/Profile.kt": "",
+		"scripts/tool.kt":                     "",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(root, path)
+		os.MkdirAll(filepath.Dir(fullPath), 0o755)
+		os.WriteFile(fullPath, []byte(content), 0o644)
+	}
+
+	oldDir, _ := os.Getwd()
+	defer os.Chdir(oldDir)
+	os.Chdir(root)
+
+	modules := map[string]string{
+		"app/src/main/java/Main.kt":                  ":app",
+		"feature/user-profile/src/Profile.kt":        ":feature:user-profile",
+		filepath.Join(root, "app/src/main/res"):      ":app",
+		"scripts/tool.kt":                            ":",
+		"feature/user-profile/src/main/res/values/x": ":feature:user-profile",
+	}
+	for path, expected := range modules {
+		if got := ModuleOfPath(path); got != expected {
+			t.Errorf("ModuleOfPath(%v) = %v, want %v", path, got, expected)
+		}
+	}
+
+	tests := []struct {
+		module     string
+		dependency string
+		expected   bool
+	}{
+		{module: ":app", dependency: ":app", expected: true},
+		{module: ":app", dependency: ":core", expected: true},
+		{module: ":app", dependency: ":feature:user-profile", expected: true},
+		{module: ":app", dependency: ":feature:login", expected: false},
+		{module: ":app", dependency: ":feature:login:api", expected: true},
+		{module: ":app", dependency: ":design", expected: false},
+		{module: ":core", dependency: ":design", expected: true},
+		{module: ":feature:user-profile", dependency: ":app", expected: false},
+		{module: ":missing", dependency: ":app", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.module+"->"+tt.dependency, func(t *testing.T) {
+			if got := ModuleDependsOn(tt.module, tt.dependency); got != tt.expected {
+				t.Errorf("ModuleDependsOn(%v, %v) = %v, want %v", tt.module, tt.dependency, got, tt.expected)
+			}
+		})
+	}
+}
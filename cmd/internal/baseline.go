@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+const DefaultBaselineFileName = "polyglot-baseline.json"
+
+// Findings accepted in a project, which check does not report again. Similar
+// to the baselines of Android Lint.
+type Baseline struct {
+	Findings []BaselineEntry `json:"findings"`
+}
+
+// A finding of the baseline. Lines are not stored, so editing other resources
+// of the file does not invalidate the baseline.
+type BaselineEntry struct {
+	RuleID  string `json:"ruleId"`
+	File    string `json:"file"`
+	Key     string `json:"key,omitempty"`
+	Locale  string `json:"locale,omitempty"`
+	Message string `json:"message"`
+}
+
+// Identity of the finding used to match it with the baseline
+func (e BaselineEntry) id() string {
+	return fmt.Sprintf("%v\x00%v\x00%v\x00%v", e.RuleID, e.File, e.Key, e.Locale)
+}
+
+func baselineEntry(f Finding) BaselineEntry {
+	return BaselineEntry{RuleID: f.RuleID, File: f.File, Key: f.Key, Locale: f.Locale, Message: f.Message}
+}
+
+func NewBaseline(findings []Finding) Baseline {
+	baseline := Baseline{Findings: []BaselineEntry{}}
+	for _, f := range findings {
+		baseline.Findings = append(baseline.Findings, baselineEntry(f))
+	}
+
+	return baseline
+}
+
+func ReadBaseline(path string) (Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Baseline{}, err
+	}
+
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return Baseline{}, fmt.Errorf("error reading baseline %v: %v", path, err)
+	}
+
+	return baseline, nil
+}
+
+func (b Baseline) Write(path string) error {
+	var data bytes.Buffer
+
+	encoder := json.NewEncoder(&data)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(b); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data.Bytes(), 0o644)
+}
+
+// Split findings into the ones that are not in the baseline and return the
+// entries of the baseline that were not found anymore. Only entries for which
+// checked returns true can be fixed, so checking a single rule or resource
+// directory does not report the others as fixed.
+func (b Baseline) Filter(findings []Finding, checked func(BaselineEntry) bool) ([]Finding, []BaselineEntry) {
+	remaining := map[string]int{}
+	for _, e := range b.Findings {
+		remaining[e.id()]++
+	}
+
+	newFindings := []Finding{}
+	for _, f := range findings {
+		id := baselineEntry(f).id()
+		if remaining[id] > 0 {
+			remaining[id]--
+			continue
+		}
+
+		newFindings = append(newFindings, f)
+	}
+
+	fixed := []BaselineEntry{}
+	for _, e := range b.Findings {
+		id := e.id()
+		if remaining[id] > 0 && checked(e) {
+			remaining[id]--
+			fixed = append(fixed, e)
+		}
+	}
+
+	return newFindings, fixed
+}
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBaselineFilter(t *testing.T) {
+	baseline := Baseline{Findings: []BaselineEntry{
+		{RuleID: RuleUnsorted, File: "values/strings.xml", Key: "a", Locale: "en", Message: "unsorted"},
+		{RuleID: RuleMissingTranslation, File: "values-pt/strings.xml", Key: "b", Locale: "pt", Message: "missing b"},
+		{RuleID: RuleMissingTranslation, File: "values-pt/strings.xml", Key: "c", Locale: "pt", Message: "missing c"},
+		{RuleID: RuleMissingTranslation, File: "lib/values-pt/strings.xml", Key: "d", Locale: "pt", Message: "missing d"},
+	}}
+
+	findings := []Finding{
+		// Lines and messages may change without invalidating the baseline
+		{RuleID: RuleUnsorted, File: "values/strings.xml", Line: 12, Key: "a", Locale: "en", Message: "unsorted again"},
+		{RuleID: RuleMissingTranslation, File: "values-pt/strings.xml", Key: "b", Locale: "pt", Message: "missing b"},
+		{RuleID: RulePlaceholderMismatch, File: "values-pt/strings.xml", Line: 3, Key: "b", Locale: "pt", Message: "new"},
+	}
+
+	checked := func(e BaselineEntry) bool { return filepath.Dir(filepath.Dir(e.File)) == "." }
+
+	newFindings, fixed := baseline.Filter(findings, checked)
+
+	expectedNew := []Finding{findings[2]}
+	if !reflect.DeepEqual(newFindings, expectedNew) {
+		t.Errorf("Filter() new = %+v, want %+v", newFindings, expectedNew)
+	}
+
+	expectedFixed := []BaselineEntry{baseline.Findings[2]}
+	if !reflect.DeepEqual(fixed, expectedFixed) {
+		t.Errorf("Filter() fixed = %+v, want %+v", fixed, expectedFixed)
+	}
+}
+
+func TestBaselineFilterRepeatedFindings(t *testing.T) {
+	finding := Finding{RuleID: RuleUnused, File: "values/strings.xml", Key: "a", Locale: "en", Message: "unused"}
+	baseline := NewBaseline([]Finding{finding})
+
+	newFindings, fixed := baseline.Filter([]Finding{finding, finding}, func(BaselineEntry) bool { return true })
+
+	if !reflect.DeepEqual(newFindings, []Finding{finding}) {
+		t.Errorf("Filter() new = %+v, want %+v", newFindings, []Finding{finding})
+	}
+	if len(fixed) != 0 {
+		t.Errorf("Filter() fixed = %+v, want none", fixed)
+	}
+}
+
+func TestBaselineWriteAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultBaselineFileName)
+
+	baseline := NewBaseline([]Finding{
+		{RuleID: RulePlaceholderMismatch, Severity: SeverityError, File: "values-pt/strings.xml", Line: 3, Key: "welcome", Locale: "pt", Message: "<welcome> missing %1$s"},
+	})
+
+	if err := baseline.Write(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := ReadBaseline(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := Baseline{Findings: []BaselineEntry{
+		{RuleID: RulePlaceholderMismatch, File: "values-pt/strings.xml", Key: "welcome", Locale: "pt", Message: "<welcome> missing %1$s"},
+	}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ReadBaseline() = %+v, want %+v", got, expected)
+	}
+}
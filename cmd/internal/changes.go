@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Changes to files that are staged in memory, so every change of a command
+// can be previewed as a diff before any file is written
+type FileChanges struct {
+	paths  []string
+	before map[string][]byte
+	after  map[string][]byte
+}
+
+func NewFileChanges() *FileChanges {
+	return &FileChanges{before: map[string][]byte{}, after: map[string][]byte{}}
+}
+
+func changeKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+
+	return filepath.Clean(path)
+}
+
+// Content of the file in path with the changes staged so far. A file that
+// does not exist is empty.
+func (c *FileChanges) Read(path string) ([]byte, error) {
+	key := changeKey(path)
+	if content, ok := c.after[key]; ok {
+		return content, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// Stage the new content of the file in path
+func (c *FileChanges) Set(path string, content []byte) error {
+	key := changeKey(path)
+	if _, ok := c.after[key]; !ok {
+		before, err := c.Read(path)
+		if err != nil {
+			return err
+		}
+
+		c.paths = append(c.paths, path)
+		c.before[key] = before
+	}
+
+	c.after[key] = content
+	return nil
+}
+
+// Paths of the files whose content changes, in the order they were staged
+func (c *FileChanges) Paths() []string {
+	paths := []string{}
+	for _, p := range c.paths {
+		if !bytes.Equal(c.before[changeKey(p)], c.after[changeKey(p)]) {
+			paths = append(paths, p)
+		}
+	}
+
+	return paths
+}
+
+// Unified diff of every changed file
+func (c *FileChanges) Diff() (string, error) {
+	var diff strings.Builder
+	for _, p := range c.Paths() {
+		d, err := UnifiedDiff(p, c.before[changeKey(p)], c.after[changeKey(p)])
+		if err != nil {
+			return "", err
+		}
+
+		diff.WriteString(d)
+	}
+
+	return diff.String(), nil
+}
+
+// Write every changed file, creating the directories that do not exist yet
+func (c *FileChanges) Write() error {
+	for _, p := range c.Paths() {
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(p, c.after[changeKey(p)], 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
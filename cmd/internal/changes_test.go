@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFileChanges(t *testing.T) {
+	root := t.TempDir()
+	existing := filepath.Join(root, "existing.txt")
+	unchanged := filepath.Join(root, "unchanged.txt")
+	created := filepath.Join(root, "new", "created.txt")
+	os.WriteFile(existing, []byte("a\nb\n"), 0o644)
+	os.WriteFile(unchanged, []byte("c\n"), 0o644)
+
+	changes := NewFileChanges()
+	changes.Set(existing, []byte("a\nB\n"))
+	changes.Set(unchanged, []byte("c\n"))
+	changes.Set(created, []byte("d\n"))
+
+	content, err := changes.Read(existing)
+	if err != nil || string(content) != "a\nB\n" {
+		t.Errorf("Read() = %q, %v, want the staged content", content, err)
+	}
+
+	if got := changes.Paths(); !reflect.DeepEqual(got, []string{existing, created}) {
+		t.Errorf("Paths() = %v, want %v", got, []string{existing, created})
+	}
+
+	diff, err := changes.Diff()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	name := FindingPath(existing)
+	expected := "--- a/" + name + "\n+++ b/" + name + "\n@@ -1,2 +1,2 @@\n a\n-b\n+B\n"
+	if !strings.HasPrefix(diff, expected) {
+		t.Errorf("Diff() = %q, want it to start with %q", diff, expected)
+	}
+
+	if err := changes.Write(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for path, want := range map[string]string{existing: "a\nB\n", unchanged: "c\n", created: "d\n"} {
+		if got, _ := os.ReadFile(path); string(got) != want {
+			t.Errorf("%v = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+	"gopkg.in/yaml.v3"
+)
+
+const ConfigFileName = ".polyglot.yaml"
+
+// Rules reported by the check command
+const (
+	RuleUnsorted            = "unsorted"
+	RuleUnused              = "unused"
+	RuleMissingTranslation  = "missing-translation"
+	RulePlaceholderMismatch = "placeholder-mismatch"
+)
+
+// Policies used to keep the resources of strings.xml in order
+const (
+	SortByKey  = "key"
+	SortByNone = "none"
+)
+
+func CheckRules() []string {
+	return []string{RuleUnsorted, RuleUnused, RuleMissingTranslation, RulePlaceholderMismatch}
+}
+
+func SortPolicies() []string {
+	return []string{SortByKey, SortByNone}
+}
+
+// Resource qualifier of a locale, e.g. pt or pt-rBR
+var qualifierRegex = regexp.MustCompile(`^([a-z]{2,3})(?:-r([A-Z]{2}))?$`)
+
+// Project configuration read from .polyglot.yaml. Every field is optional and
+// command flags take precedence over it.
+type Config struct {
+	// Translation provider used when --provider and POLYGLOT_TRANSLATION_PROVIDER are not set
+	Provider string `yaml:"provider"`
+	// Environment variable holding the API key of the provider
+	ApiKeyEnv string `yaml:"api_key_env"`
+	// Locale of the default values/strings.xml, defaults to en
+	SourceLocale string `yaml:"source_locale"`
+	// Locales translated by translate and sync, all of them when empty
+	TargetLocales []string `yaml:"target_locales"`
+	// Gradle modules whose resource directories are ignored, e.g. :benchmark
+	ExcludeModules []string `yaml:"exclude_modules"`
+	// Keys ignored by check and sync, glob patterns such as debug_* are accepted
+	ExcludeKeys []string `yaml:"exclude_keys"`
+	// Keys that are always considered used, e.g. loaded by reflection. They
+	// are not reported by the unused rule nor removed by remove --unused.
+	KeepKeys []string `yaml:"keep_keys"`
+	// Settings of the check command
+	Check CheckConfig `yaml:"check"`
+	// How resources are ordered: key (default) or none
+	Sort string `yaml:"sort"`
+
+	// Path of the file the configuration was read from, empty if there is none
+	Path string `yaml:"-"`
+}
+
+type CheckConfig struct {
+	// Rules reported by check, all of them when empty
+	Rules []string `yaml:"rules"`
+	// Severity of each rule: error, warning, info or off
+	Severity map[string]string `yaml:"severity"`
+	// Path of the baseline file, polyglot-baseline.json when empty
+	Baseline string `yaml:"baseline"`
+	// Sources searched for references by the unused rule, e.g. java: false.
+	// Sources not listed are searched.
+	UsageSources map[string]bool `yaml:"usage_sources"`
+}
+
+// Find .polyglot.yaml in dir or in the closest parent directory that has one
+func FindConfigFile(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		p := filepath.Join(dir, ConfigFileName)
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Configuration of the project in the current directory. An empty Config is
+// returned when the project has no configuration file.
+func LoadConfig() (Config, error) {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return Config{}, err
+	}
+
+	p, ok := FindConfigFile(currentDir)
+	if !ok {
+		return Config{}, nil
+	}
+
+	return ReadConfig(p)
+}
+
+// Read and validate a configuration file. Unknown fields are reported so
+// typos do not silently disable a setting.
+func ReadConfig(p string) (Config, error) {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config := Config{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("error reading %v: %v", p, err)
+	}
+
+	config.Path = p
+
+	if err := config.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid configuration %v:\n%v", p, err)
+	}
+
+	return config, nil
+}
+
+// Report every invalid value of the configuration
+func (c Config) Validate() error {
+	problems := []error{}
+
+	if c.Provider != "" && !slices.Contains(AvailableProviders(), strings.ToLower(c.Provider)) {
+		problems = append(problems, fmt.Errorf("provider: unknown translation provider %q, available providers: %v", c.Provider, strings.Join(AvailableProviders(), ", ")))
+	}
+
+	if c.SourceLocale != "" && !qualifierRegex.MatchString(c.SourceLocale) {
+		problems = append(problems, fmt.Errorf("source_locale: invalid locale %q, use a qualifier such as en or pt-rBR", c.SourceLocale))
+	}
+
+	for _, locale := range c.TargetLocales {
+		if !qualifierRegex.MatchString(locale) {
+			problems = append(problems, fmt.Errorf("target_locales: invalid locale %q, use a qualifier such as es or pt-rBR", locale))
+		}
+	}
+
+	for _, module := range c.ExcludeModules {
+		if strings.Trim(module, ":") == "" {
+			problems = append(problems, fmt.Errorf("exclude_modules: invalid module %q", module))
+		}
+	}
+
+	for _, pattern := range c.ExcludeKeys {
+		if !IsValidKeyPattern(pattern) {
+			problems = append(problems, fmt.Errorf("exclude_keys: invalid pattern %q", pattern))
+		}
+	}
+
+	for _, pattern := range c.KeepKeys {
+		if !IsValidKeyPattern(pattern) {
+			problems = append(problems, fmt.Errorf("keep_keys: invalid pattern %q", pattern))
+		}
+	}
+
+	for _, rule := range c.Check.Rules {
+		if !slices.Contains(CheckRules(), rule) {
+			problems = append(problems, fmt.Errorf("check.rules: unknown rule %q, available rules: %v", rule, strings.Join(CheckRules(), ", ")))
+		}
+	}
+
+	for _, rule := range slices.Sorted(maps.Keys(c.Check.Severity)) {
+		severity := c.Check.Severity[rule]
+		if !slices.Contains(CheckRules(), rule) {
+			problems = append(problems, fmt.Errorf("check.severity: unknown rule %q, available rules: %v", rule, strings.Join(CheckRules(), ", ")))
+		}
+		if severity != SeverityOff && !slices.Contains(Severities(), severity) {
+			problems = append(problems, fmt.Errorf("check.severity.%v: unknown severity %q, available severities: %v, %v", rule, severity, strings.Join(Severities(), ", "), SeverityOff))
+		}
+	}
+
+	for _, source := range slices.Sorted(maps.Keys(c.Check.UsageSources)) {
+		if !slices.Contains(UsageSources(), source) {
+			problems = append(problems, fmt.Errorf("check.usage_sources: unknown source %q, available sources: %v", source, strings.Join(UsageSources(), ", ")))
+		}
+	}
+
+	if c.Sort != "" && !slices.Contains(SortPolicies(), c.Sort) {
+		problems = append(problems, fmt.Errorf("sort: unknown policy %q, available policies: %v", c.Sort, strings.Join(SortPolicies(), ", ")))
+	}
+
+	return errors.Join(problems...)
+}
+
+// Language of the default values/strings.xml, English when not configured
+func (c Config) SourceTranslation() Translation {
+	if c.SourceLocale == "" {
+		return DefaultSourceTranslation
+	}
+
+	t, err := TranslationFromQualifier(c.SourceLocale)
+	if err != nil {
+		return DefaultSourceTranslation
+	}
+
+	return t
+}
+
+// Check if resources should be kept sorted by key
+func (c Config) SortsByKey() bool {
+	return c.Sort != SortByNone
+}
+
+// Check if rule should be reported. Rules are enabled when none is configured,
+// the unsorted rule is disabled by the none sort policy and any rule is
+// disabled by the off severity.
+func (c Config) IsRuleEnabled(rule string) bool {
+	if rule == RuleUnsorted && !c.SortsByKey() {
+		return false
+	}
+
+	if c.Check.Severity[rule] == SeverityOff {
+		return false
+	}
+
+	return len(c.Check.Rules) == 0 || slices.Contains(c.Check.Rules, rule)
+}
+
+// Severity of the findings of rule, its default severity when not configured
+func (c Config) RuleSeverity(rule string) string {
+	severity, ok := c.Check.Severity[rule]
+	if !ok {
+		return DefaultSeverity(rule)
+	}
+
+	return severity
+}
+
+// Sources searched for references to resources, every source not turned off
+func (c Config) EnabledUsageSources() []string {
+	sources := []string{}
+	for _, source := range UsageSources() {
+		if enabled, ok := c.Check.UsageSources[source]; !ok || enabled {
+			sources = append(sources, source)
+		}
+	}
+
+	return sources
+}
+
+// Check if the key matches one of the excluded keys
+func (c Config) IsKeyExcluded(key string) bool {
+	return MatchesKeyPattern(c.ExcludeKeys, key)
+}
+
+// Check if the key matches one of the keys that are always considered used
+func (c Config) IsKeyKept(key string) bool {
+	return MatchesKeyPattern(c.KeepKeys, key)
+}
+
+// Check if pattern is a glob pattern keys can be matched with
+func IsValidKeyPattern(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil && pattern != ""
+}
+
+// Check if the key matches one of the glob patterns, such as debug_*
+func MatchesKeyPattern(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Directories of the excluded modules, relative to the project root
+func (c Config) ExcludedModuleDirectories() []string {
+	dirs := []string{}
+	for _, module := range c.ExcludeModules {
+		dirs = append(dirs, ModuleDirectory(module))
+	}
+
+	return dirs
+}
+
+// Check if the translation matches one of the target locales
+func (c Config) IsTargetLocale(t Translation) bool {
+	return len(c.TargetLocales) == 0 || slices.ContainsFunc(c.TargetLocales, t.MatchesLocale)
+}
+
+var languageTagRegex = regexp.MustCompile(`^([a-zA-Z]{2,3})(?:[-_]([a-zA-Z]{2}))?$`)
+
+// Translation of a locale given as a BCP 47 language tag, e.g. pt-BR, as used
+// by translation exchange formats. Tags with a script or a variant have no
+// resource qualifier and are rejected.
+func TranslationFromLanguageTag(tag string) (Translation, error) {
+	matches := languageTagRegex.FindStringSubmatch(tag)
+	if matches == nil {
+		return Translation{}, fmt.Errorf("unsupported language tag %q, use a language with an optional region such as pt or pt-BR", tag)
+	}
+
+	qualifier := strings.ToLower(matches[1])
+	if matches[2] != "" {
+		qualifier += "-r" + strings.ToUpper(matches[2])
+	}
+
+	return TranslationFromQualifier(qualifier)
+}
+
+// Translation of a locale given as a resource qualifier, e.g. pt-rBR
+func TranslationFromQualifier(qualifier string) (Translation, error) {
+	matches := qualifierRegex.FindStringSubmatch(qualifier)
+	if matches == nil {
+		return Translation{}, fmt.Errorf("invalid locale %q", qualifier)
+	}
+
+	locale, region := matches[1], matches[2]
+
+	languageTag := locale
+	if region != "" {
+		languageTag += "-" + region
+	}
+
+	tag, err := language.Parse(languageTag)
+	if err != nil {
+		return Translation{}, fmt.Errorf("error parsing language tag: %v", err)
+	}
+
+	return Translation{
+		LocaleCode: locale,
+		RegionCode: region,
+		Language:   display.English.Languages().Name(tag),
+	}, nil
+}
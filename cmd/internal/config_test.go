@@ -0,0 +1,274 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expected      Config
+		errorContains []string
+	}{
+		{
+			name:     "Empty file",
+			content:  "",
+			expected: Config{},
+		},
+		{
+			name: "Every field",
+			content: `provider: deepl
+api_key_env: PALMEIRAS_DEEPL_KEY
+source_locale: pt-rBR
+target_locales: [en, es]
+exclude_modules: [":benchmark"]
+exclude_keys: ["debug_*"]
+keep_keys: ["promo_*"]
+check:
+  rules: [unsorted, placeholder-mismatch]
+  severity:
+    unused: off
+    placeholder-mismatch: warning
+  usage_sources:
+    get-identifier: false
+sort: none
+`,
+			expected: Config{
+				Provider:       "deepl",
+				ApiKeyEnv:      "PALMEIRAS_DEEPL_KEY",
+				SourceLocale:   "pt-rBR",
+				TargetLocales:  []string{"en", "es"},
+				ExcludeModules: []string{":benchmark"},
+				ExcludeKeys:    []string{"debug_*"},
+				KeepKeys:       []string{"promo_*"},
+				Sort:           SortByNone,
+				Check: CheckConfig{
+					Rules:        []string{RuleUnsorted, RulePlaceholderMismatch},
+					Severity:     map[string]string{RuleUnused: SeverityOff, RulePlaceholderMismatch: SeverityWarning},
+					UsageSources: map[string]bool{UsageSourceGetIdentifier: false},
+				},
+			},
+		},
+		{
+			name:          "Unknown field",
+			content:       "provder: deepl\n",
+			errorContains: []string{"field provder not found"},
+		},
+		{
+			name: "Invalid values",
+			content: `provider: palmeiras
+source_locale: portuguese
+target_locales: [pt_BR]
+exclude_keys: ["debug_["]
+keep_keys: [""]
+check:
+  rules: [unsorted, typo]
+  severity:
+    unsorted: fatal
+    typo: error
+  usage_sources:
+    swift: true
+sort: value
+`,
+			errorContains: []string{
+				`provider: unknown translation provider "palmeiras"`,
+				`source_locale: invalid locale "portuguese"`,
+				`target_locales: invalid locale "pt_BR"`,
+				`exclude_keys: invalid pattern "debug_["`,
+				`keep_keys: invalid pattern ""`,
+				`check.rules: unknown rule "typo"`,
+				`check.severity.unsorted: unknown severity "fatal"`,
+				`check.severity: unknown rule "typo"`,
+				`check.usage_sources: unknown source "swift"`,
+				`sort: unknown policy "value"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ConfigFileName)
+			os.WriteFile(path, []byte(tt.content), 0o644)
+
+			got, err := ReadConfig(path)
+			if len(tt.errorContains) > 0 {
+				if err == nil {
+					t.Fatalf("ReadConfig() expected an error")
+				}
+				for _, e := range tt.errorContains {
+					if !strings.Contains(err.Error(), e) {
+						t.Errorf("ReadConfig() error = %v, want %v", err, e)
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			tt.expected.Path = path
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ReadConfig() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindConfigFile(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "app", "src")
+	os.MkdirAll(nested, 0o755)
+
+	if _, ok := FindConfigFile(nested); ok {
+		t.Errorf("FindConfigFile() found a file that does not exist")
+	}
+
+	path := filepath.Join(root, ConfigFileName)
+	os.WriteFile(path, []byte("sort: key\n"), 0o644)
+
+	got, ok := FindConfigFile(nested)
+	if !ok || got != path {
+		t.Errorf("FindConfigFile() = %v, %v, want %v", got, ok, path)
+	}
+}
+
+func TestConfigIsRuleEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		rule     string
+		expected bool
+	}{
+		{name: "Every rule by default", config: Config{}, rule: RuleUnused, expected: true},
+		{name: "Configured rule", config: Config{Check: CheckConfig{Rules: []string{RuleUnused}}}, rule: RuleUnused, expected: true},
+		{name: "Rule not configured", config: Config{Check: CheckConfig{Rules: []string{RuleUnused}}}, rule: RuleMissingTranslation, expected: false},
+		{name: "Unsorted disabled by sort policy", config: Config{Sort: SortByNone}, rule: RuleUnsorted, expected: false},
+		{name: "Rule turned off", config: Config{Check: CheckConfig{Severity: map[string]string{RuleUnused: SeverityOff}}}, rule: RuleUnused, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.IsRuleEnabled(tt.rule); got != tt.expected {
+				t.Errorf("IsRuleEnabled() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfigIsKeyExcluded(t *testing.T) {
+	config := Config{ExcludeKeys: []string{"debug_*", "app_name"}}
+
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{key: "debug_menu", expected: true},
+		{key: "app_name", expected: true},
+		{key: "app_name_short", expected: false},
+		{key: "palmeiras", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := config.IsKeyExcluded(tt.key); got != tt.expected {
+				t.Errorf("IsKeyExcluded(%v) = %v, want %v", tt.key, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfigSourceTranslation(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected Translation
+	}{
+		{name: "Default", config: Config{}, expected: DefaultSourceTranslation},
+		{name: "Language", config: Config{SourceLocale: "es"}, expected: Translation{Language: "Spanish", LocaleCode: "es"}},
+		{name: "Language with region", config: Config{SourceLocale: "pt-rBR"}, expected: Translation{Language: "Brazilian Portuguese", LocaleCode: "pt", RegionCode: "BR"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.SourceTranslation(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("SourceTranslation() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTranslationFromLanguageTag(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected Translation
+		wantErr  bool
+	}{
+		{tag: "es", expected: Translation{Language: "Spanish", LocaleCode: "es"}},
+		{tag: "pt-BR", expected: Translation{Language: "Brazilian Portuguese", LocaleCode: "pt", RegionCode: "BR"}},
+		{tag: "pt_br", expected: Translation{Language: "Brazilian Portuguese", LocaleCode: "pt", RegionCode: "BR"}},
+		{tag: "zh-Hans", wantErr: true},
+		{tag: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := TranslationFromLanguageTag(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TranslationFromLanguageTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("TranslationFromLanguageTag() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfigEnabledUsageSources(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected []string
+	}{
+		{name: "Every source by default", config: Config{}, expected: UsageSources()},
+		{
+			name:     "Sources turned off",
+			config:   Config{Check: CheckConfig{UsageSources: map[string]bool{UsageSourceJava: false, UsageSourceXML: true, UsageSourceGetIdentifier: false}}},
+			expected: []string{UsageSourceKotlin, UsageSourceXML, UsageSourceComposeResources},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.EnabledUsageSources(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("EnabledUsageSources() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfigRuleSeverity(t *testing.T) {
+	config := Config{Check: CheckConfig{Severity: map[string]string{RuleUnused: SeverityInfo}}}
+
+	tests := []struct {
+		rule     string
+		expected string
+	}{
+		{rule: RuleUnused, expected: SeverityInfo},
+		{rule: RuleUnsorted, expected: SeverityWarning},
+		{rule: RulePlaceholderMismatch, expected: SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			if got := config.RuleSeverity(tt.rule); got != tt.expected {
+				t.Errorf("RuleSeverity(%v) = %v, want %v", tt.rule, got, tt.expected)
+			}
+		})
+	}
+}
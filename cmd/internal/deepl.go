@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	ProviderDeepL = "deepl"
+
+	deepLFreeURL = "https://api-free.deepl.com"
+	deepLProURL  = "https://api.deepl.com"
+)
+
+var DEEPL_AUTH_KEY = os.Getenv("DEEPL_AUTH_KEY")
+
+var DeepLFormalities = []string{"default", "more", "less", "prefer_more", "prefer_less"}
+
+// Translator backed by the DeepL API. Keys of the free plan end with ":fx"
+// and are sent to the free endpoint, any other key to the pro endpoint.
+type DeepLTranslator struct {
+	authKey   string
+	formality string
+	baseURL   string
+	client    *http.Client
+}
+
+func NewDeepLTranslator(authKey, formality string) (*DeepLTranslator, error) {
+	key := DEEPL_AUTH_KEY
+	if authKey != "" {
+		key = authKey
+	}
+
+	if key == "" {
+		return nil, fmt.Errorf("you need to pass the key through --deeplAuthKey flag or set the DEEPL_AUTH_KEY environment variable to use DeepL")
+	}
+
+	if formality != "" && !slices.Contains(DeepLFormalities, formality) {
+		return nil, fmt.Errorf("invalid DeepL formality %q, available options: %v", formality, strings.Join(DeepLFormalities, ", "))
+	}
+
+	baseURL := deepLProURL
+	if strings.HasSuffix(key, ":fx") {
+		baseURL = deepLFreeURL
+	}
+
+	return &DeepLTranslator{
+		authKey:   key,
+		formality: formality,
+		baseURL:   baseURL,
+		client:    http.DefaultClient,
+	}, nil
+}
+
+func (d *DeepLTranslator) Name() string {
+	return ProviderDeepL
+}
+
+type deepLTranslateRequest struct {
+	Text       []string `json:"text"`
+	SourceLang string   `json:"source_lang,omitempty"`
+	TargetLang string   `json:"target_lang"`
+	Formality  string   `json:"formality,omitempty"`
+}
+
+type deepLTranslateResponse struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+}
+
+type deepLLanguage struct {
+	Language string `json:"language"`
+	Name     string `json:"name"`
+}
+
+func (d *DeepLTranslator) Translate(ctx context.Context, texts []string, source, target Translation) ([]string, error) {
+	request := deepLTranslateRequest{
+		Text:       texts,
+		SourceLang: DeepLSourceLanguage(source),
+		TargetLang: DeepLTargetLanguage(target),
+	}
+
+	if d.formality != "" && d.formality != "default" {
+		request.Formality = d.formality
+	}
+
+	var response deepLTranslateResponse
+	err := d.do(ctx, http.MethodPost, "/v2/translate", request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	translated := []string{}
+	for _, t := range response.Translations {
+		translated = append(translated, t.Text)
+	}
+
+	return translated, nil
+}
+
+func (d *DeepLTranslator) SupportedLanguages(ctx context.Context) ([]string, error) {
+	var languages []deepLLanguage
+	err := d.do(ctx, http.MethodGet, "/v2/languages?type=target", nil, &languages)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := []string{}
+	for _, l := range languages {
+		codes = append(codes, l.Language)
+	}
+
+	return codes, nil
+}
+
+func (d *DeepLTranslator) do(ctx context.Context, method, path string, body any, response any) error {
+	headers := map[string]string{"Authorization": "DeepL-Auth-Key " + d.authKey}
+	return doJSONRequest(ctx, d.client, method, d.baseURL+path, headers, body, response)
+}
+
+// Map the locale of a values-xx[-rYY] directory to a DeepL target language code
+func DeepLTargetLanguage(t Translation) string {
+	locale := strings.ToLower(t.LocaleCode)
+	region := strings.ToUpper(t.RegionCode)
+
+	switch locale {
+	case "en":
+		if region == "GB" {
+			return "EN-GB"
+		}
+		return "EN-US"
+	case "pt":
+		if region == "PT" {
+			return "PT-PT"
+		}
+		// Brazilian devices fall back to values-pt, the most common use of it
+		return "PT-BR"
+	case "zh":
+		if region == "TW" || region == "HK" || region == "MO" {
+			return "ZH-HANT"
+		}
+		return "ZH-HANS"
+	}
+
+	return DeepLSourceLanguage(t)
+}
+
+// Map the locale of a values-xx directory to a DeepL source language code,
+// which never carries a region
+func DeepLSourceLanguage(t Translation) string {
+	switch locale := strings.ToLower(t.LocaleCode); locale {
+	// Android still uses the deprecated ISO 639 codes for these languages
+	case "iw":
+		return "HE"
+	case "in":
+		return "ID"
+	case "no":
+		return "NB"
+	default:
+		return strings.ToUpper(locale)
+	}
+}
@@ -0,0 +1,160 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestDeepLTargetLanguage(t *testing.T) {
+	tests := []struct {
+		name        string
+		translation Translation
+		expected    string
+	}{
+		{name: "Brazilian Portuguese", translation: Translation{LocaleCode: "pt", RegionCode: "BR"}, expected: "PT-BR"},
+		{name: "European Portuguese", translation: Translation{LocaleCode: "pt", RegionCode: "PT"}, expected: "PT-PT"},
+		{name: "Portuguese without region", translation: Translation{LocaleCode: "pt"}, expected: "PT-BR"},
+		{name: "Chinese without region", translation: Translation{LocaleCode: "zh"}, expected: "ZH-HANS"},
+		{name: "Chinese from China", translation: Translation{LocaleCode: "zh", RegionCode: "CN"}, expected: "ZH-HANS"},
+		{name: "Chinese from Taiwan", translation: Translation{LocaleCode: "zh", RegionCode: "TW"}, expected: "ZH-HANT"},
+		{name: "British English", translation: Translation{LocaleCode: "en", RegionCode: "GB"}, expected: "EN-GB"},
+		{name: "English without region", translation: Translation{LocaleCode: "en"}, expected: "EN-US"},
+		{name: "Hebrew with legacy code", translation: Translation{LocaleCode: "iw"}, expected: "HE"},
+		{name: "Spanish with region", translation: Translation{LocaleCode: "es", RegionCode: "MX"}, expected: "ES"},
+		{name: "German", translation: Translation{LocaleCode: "de"}, expected: "DE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DeepLTargetLanguage(tt.translation)
+			if got != tt.expected {
+				t.Errorf("DeepLTargetLanguage() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewDeepLTranslator(t *testing.T) {
+	t.Run("Missing key", func(t *testing.T) {
+		DEEPL_AUTH_KEY = ""
+		defer func() { DEEPL_AUTH_KEY = os.Getenv("DEEPL_AUTH_KEY") }()
+
+		_, err := NewDeepLTranslator("", "")
+		if err == nil {
+			t.Errorf("Expected an error, but got none")
+		}
+	})
+
+	t.Run("Invalid formality", func(t *testing.T) {
+		_, err := NewDeepLTranslator("KEY", "polite")
+		if err == nil {
+			t.Errorf("Expected an error, but got none")
+		}
+	})
+
+	t.Run("Free key uses free endpoint", func(t *testing.T) {
+		translator, err := NewDeepLTranslator("KEY:fx", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if translator.baseURL != deepLFreeURL {
+			t.Errorf("baseURL = %v, want %v", translator.baseURL, deepLFreeURL)
+		}
+	})
+
+	t.Run("Pro key uses pro endpoint", func(t *testing.T) {
+		translator, err := NewDeepLTranslator("KEY", "more")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if translator.baseURL != deepLProURL {
+			t.Errorf("baseURL = %v, want %v", translator.baseURL, deepLProURL)
+		}
+	})
+}
+
+func newDeepLTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "DeepL-Auth-Key KEY" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/translate":
+			var request deepLTranslateRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Fatalf("Failed to decode request: %v", err)
+			}
+
+			translations := []map[string]string{}
+			for _, text := range request.Text {
+				translations = append(translations, map[string]string{
+					"text": request.TargetLang + "|" + request.SourceLang + "|" + request.Formality + "|" + text,
+				})
+			}
+
+			json.NewEncoder(w).Encode(map[string]any{"translations": translations})
+		case "/v2/languages":
+			w.Write([]byte(`[{"language":"DE","name":"German"},{"language":"PT-BR","name":"Portuguese (Brazilian)"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestDeepLTranslatorTranslate(t *testing.T) {
+	server := newDeepLTestServer(t)
+	defer server.Close()
+
+	translator := &DeepLTranslator{authKey: "KEY", formality: "less", baseURL: server.URL, client: server.Client()}
+
+	got, err := translator.Translate(context.Background(), []string{"Hello", "World"}, DefaultSourceTranslation, Translation{LocaleCode: "pt", RegionCode: "BR"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"PT-BR|EN|less|Hello", "PT-BR|EN|less|World"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Translate() = %v, want %v", got, expected)
+	}
+
+	t.Run("Default formality is not sent", func(t *testing.T) {
+		translator := &DeepLTranslator{authKey: "KEY", formality: "default", baseURL: server.URL, client: server.Client()}
+
+		got, err := translator.Translate(context.Background(), []string{"Hi"}, DefaultSourceTranslation, Translation{LocaleCode: "de"})
+		if err != nil || !reflect.DeepEqual(got, []string{"DE|EN||Hi"}) {
+			t.Errorf("Translate() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("Error status", func(t *testing.T) {
+		translator := &DeepLTranslator{authKey: "WRONG", baseURL: server.URL, client: server.Client()}
+
+		_, err := translator.Translate(context.Background(), []string{"Hello"}, DefaultSourceTranslation, Translation{LocaleCode: "de"})
+		if err == nil {
+			t.Errorf("Expected an error, but got none")
+		}
+	})
+}
+
+func TestDeepLTranslatorSupportedLanguages(t *testing.T) {
+	server := newDeepLTestServer(t)
+	defer server.Close()
+
+	translator := &DeepLTranslator{authKey: "KEY", baseURL: server.URL, client: server.Client()}
+
+	got, err := translator.SupportedLanguages(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, []string{"DE", "PT-BR"}) {
+		t.Errorf("SupportedLanguages() = %v", got)
+	}
+}
@@ -0,0 +1,301 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Severities of the findings reported by check. A rule with the off severity
+// is not checked.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+	SeverityOff     = "off"
+)
+
+// Severities from the most to the least severe
+func Severities() []string {
+	return []string{SeverityError, SeverityWarning, SeverityInfo}
+}
+
+// Check if severity is as severe as threshold or more
+func IsSeverityAtLeast(severity, threshold string) bool {
+	index := slices.Index(Severities(), severity)
+	return index >= 0 && index <= slices.Index(Severities(), threshold)
+}
+
+// Short description of each check rule
+var RuleDescriptions = map[string]string{
+	RuleUnsorted:            "Resource file is not sorted by key",
+	RuleUnused:              "String resource appears to be unused",
+	RuleMissingTranslation:  "Resource is missing from some locales",
+	RulePlaceholderMismatch: "Format arguments differ from the default locale",
+}
+
+// Severity of each rule when it is not configured
+func DefaultSeverity(rule string) string {
+	if rule == RulePlaceholderMismatch {
+		return SeverityError
+	}
+
+	return SeverityWarning
+}
+
+// A problem found by a check rule
+type Finding struct {
+	RuleID   string `json:"ruleId"`
+	Severity string `json:"severity"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Locale   string `json:"locale,omitempty"`
+	Message  string `json:"message"`
+}
+
+// Location of the finding as file:line, or only file when the line is unknown
+func (f Finding) Location() string {
+	if f.Line == 0 {
+		return f.File
+	}
+
+	return fmt.Sprintf("%v:%v", f.File, f.Line)
+}
+
+// Lines of the resources of each file, read once per file
+type resourceLines map[string]map[string]int
+
+func (l resourceLines) line(path, reportKey string) int {
+	lines, ok := l[path]
+	if !ok {
+		lines, _ = ResourceLines(path)
+		l[path] = lines
+	}
+
+	return lines[reportKey]
+}
+
+// Locale of the translation, the language of the default values directory is used for it
+func findingLocale(t Translation) string {
+	if t.IsDefault() {
+		return t.LocaleCode
+	}
+
+	return t.Qualifier()
+}
+
+// Path of the file relative to the current directory, so reports do not
+// depend on where the project is checked out
+func FindingPath(path string) string {
+	currentDir, err := os.Getwd()
+	if err != nil || !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+
+	relative, err := filepath.Rel(currentDir, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+
+	return filepath.ToSlash(relative)
+}
+
+// Report key of the first resource that breaks the sort by key, empty if sorted
+func firstUnsortedKey(r Resources) string {
+	if key := firstUnsorted(r.Strings, func(s String) string { return s.Key }); key != "" {
+		return key
+	}
+
+	if key := firstUnsorted(r.Plurals, func(p Plurals) string { return p.Key }); key != "" {
+		return PluralsReportKey(key)
+	}
+
+	if key := firstUnsorted(r.StringArrays, func(a StringArray) string { return a.Key }); key != "" {
+		return StringArrayReportKey(key)
+	}
+
+	return ""
+}
+
+func firstUnsorted[T any](items []T, key func(T) string) string {
+	for i := 1; i < len(items); i++ {
+		if key(items[i]) < key(items[i-1]) {
+			return key(items[i])
+		}
+	}
+
+	return ""
+}
+
+// Files that are not sorted by key, pointing to the first resource out of order
+func (lr ListResources) UnsortedFindings() []Finding {
+	findings := []Finding{}
+	lines := resourceLines{}
+
+	for _, r := range lr {
+		key := firstUnsortedKey(r)
+		if key == "" {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			RuleID:   RuleUnsorted,
+			Severity: DefaultSeverity(RuleUnsorted),
+			File:     FindingPath(r.Translation.Path),
+			Line:     lines.line(r.Translation.Path, key),
+			Key:      key,
+			Locale:   findingLocale(r.Translation),
+			Message:  fmt.Sprintf("File is not sorted by key, <%v> is out of order", key),
+		})
+	}
+
+	return findings
+}
+
+// Strings that are not referenced in the code according to isUsed, reported
+// where they are defined in the default locale when possible
+func (lr ListResources) UnusedFindings(isUsed func(key string) bool) []Finding {
+	findings := []Finding{}
+	lines := resourceLines{}
+
+	// The file of each key, preferring the default values/strings.xml
+	definitions := map[string]Translation{}
+	keys := []string{}
+	for _, r := range lr {
+		for _, s := range r.Strings {
+			t, ok := definitions[s.Key]
+			if !ok {
+				keys = append(keys, s.Key)
+			}
+			if !ok || (!t.IsDefault() && r.Translation.IsDefault()) {
+				definitions[s.Key] = r.Translation
+			}
+		}
+	}
+
+	for _, key := range keys {
+		if isUsed(key) {
+			continue
+		}
+
+		t := definitions[key]
+		findings = append(findings, Finding{
+			RuleID:   RuleUnused,
+			Severity: DefaultSeverity(RuleUnused),
+			File:     FindingPath(t.Path),
+			Line:     lines.line(t.Path, key),
+			Key:      key,
+			Locale:   findingLocale(t),
+			Message:  fmt.Sprintf("String <R.string.%v> appears to be unused", key),
+		})
+	}
+
+	return findings
+}
+
+// Format arguments of translations that do not match the default locale
+func (lr ListResources) PlaceholderFindings() []Finding {
+	findings := []Finding{}
+	lines := resourceLines{}
+
+	translations := map[string]Translation{}
+	for _, r := range lr {
+		translations[r.Translation.Path] = r.Translation
+	}
+
+	for _, m := range lr.CheckPlaceholders() {
+		findings = append(findings, Finding{
+			RuleID:   RulePlaceholderMismatch,
+			Severity: DefaultSeverity(RulePlaceholderMismatch),
+			File:     FindingPath(m.Path),
+			Line:     lines.line(m.Path, m.Key),
+			Key:      m.Key,
+			Locale:   findingLocale(translations[m.Path]),
+			Message:  fmt.Sprintf("<%v> %v", m.Key, strings.Join(m.Problems, ", ")),
+		})
+	}
+
+	return findings
+}
+
+// Keys defined in some locales and missing from others of the same res
+// directory, one finding for each file the key is missing from. Modules are
+// compared on their own, a key of a module is not expected in the others.
+func (lr ListResources) MissingTranslationFindings() []Finding {
+	findings := []Finding{}
+
+	translations := []Translation{}
+	resources := map[string]Resources{}
+	for _, r := range lr {
+		translations = append(translations, r.Translation)
+		resources[r.Translation.Path] = r
+	}
+
+	for _, directory := range GroupTranslationsByResourceDirectory(translations) {
+		// Keys are compared by file rather than by language, as values and
+		// values-en have the same language
+		definedIn := map[string][]Translation{}
+		keys := []string{}
+		for _, t := range directory.Translations {
+			fileKeys := ListResources{resources[t.Path]}.CheckMissingTranslations().stringKeys
+			for key := range fileKeys {
+				if _, ok := definedIn[key]; !ok {
+					keys = append(keys, key)
+				}
+				definedIn[key] = append(definedIn[key], t)
+			}
+		}
+
+		for _, key := range keys {
+			languages := []string{}
+			for _, t := range definedIn[key] {
+				languages = append(languages, t.Language)
+			}
+
+			for _, t := range directory.Translations {
+				if slices.ContainsFunc(definedIn[key], func(d Translation) bool { return d.Path == t.Path }) {
+					continue
+				}
+
+				findings = append(findings, Finding{
+					RuleID:   RuleMissingTranslation,
+					Severity: DefaultSeverity(RuleMissingTranslation),
+					File:     FindingPath(t.Path),
+					Key:      key,
+					Locale:   findingLocale(t),
+					Message:  fmt.Sprintf("<%v> is missing from %v, defined in [%v]", key, t.Language, strings.Join(languages, ", ")),
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// Sort findings by rule, file, line and key so reports are stable between runs
+func SortFindings(findings []Finding) {
+	rules := CheckRules()
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+
+		if a.RuleID != b.RuleID {
+			return slices.Index(rules, a.RuleID) < slices.Index(rules, b.RuleID)
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+
+		return a.Locale < b.Locale
+	})
+}
@@ -0,0 +1,273 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Write strings.xml files to a temporary android project and read their resources
+func writeFindingsProject(t *testing.T, files map[string]string) ListResources {
+	tmpDir := t.TempDir()
+
+	oldDir, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(oldDir) })
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+
+	lr := ListResources{}
+	for _, dir := range []string{"values", "values-pt"} {
+		content, ok := files[dir]
+		if !ok {
+			continue
+		}
+
+		path := filepath.Join(tmpDir, "app", "src", "main", "res", dir, "strings.xml")
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		os.WriteFile(path, []byte(content), 0o644)
+
+		r, err := GetResourcesFromPathXML(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		lr = append(lr, r)
+	}
+
+	return lr
+}
+
+func TestFindings(t *testing.T) {
+	lr := writeFindingsProject(t, map[string]string{
+		"values": `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <string name="welcome">Welcome %1$s</string>
+    <string name="app_name">Palmeiras</string>
+    <plurals name="titles">
+        <item quantity="other">%d titles</item>
+    </plurals>
+</resources>
+`,
+		"values-pt": `<resources>
+    <string name="welcome">Bem-vindo %1$d</string>
+    <plurals name="titles">
+        <item quantity="other">%d títulos</item>
+    </plurals>
+</resources>
+`,
+	})
+
+	defaultPath := "app/src/main/res/values/strings.xml"
+	ptPath := "app/src/main/res/values-pt/strings.xml"
+
+	t.Run("Unsorted", func(t *testing.T) {
+		expected := []Finding{{
+			RuleID:   RuleUnsorted,
+			Severity: SeverityWarning,
+			File:     defaultPath,
+			Line:     4,
+			Key:      "app_name",
+			Locale:   "en",
+			Message:  "File is not sorted by key, <app_name> is out of order",
+		}}
+
+		got := lr.UnsortedFindings()
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("UnsortedFindings() = %+v, want %+v", got, expected)
+		}
+	})
+
+	t.Run("Unused", func(t *testing.T) {
+		isUsed := func(key string) bool { return key == "welcome" }
+
+		expected := []Finding{{
+			RuleID:   RuleUnused,
+			Severity: SeverityWarning,
+			File:     defaultPath,
+			Line:     4,
+			Key:      "app_name",
+			Locale:   "en",
+			Message:  "String <R.string.app_name> appears to be unused",
+		}}
+
+		got := lr.UnusedFindings(isUsed)
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("UnusedFindings() = %+v, want %+v", got, expected)
+		}
+	})
+
+	t.Run("Placeholder mismatch", func(t *testing.T) {
+		expected := []Finding{{
+			RuleID:   RulePlaceholderMismatch,
+			Severity: SeverityError,
+			File:     ptPath,
+			Line:     2,
+			Key:      "welcome",
+			Locale:   "pt",
+			Message:  "<welcome> wrong type %1$d (expected %1$s)",
+		}}
+
+		got := lr.PlaceholderFindings()
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("PlaceholderFindings() = %+v, want %+v", got, expected)
+		}
+	})
+
+	t.Run("Missing translation", func(t *testing.T) {
+		expected := []Finding{{
+			RuleID:   RuleMissingTranslation,
+			Severity: SeverityWarning,
+			File:     ptPath,
+			Key:      "app_name",
+			Locale:   "pt",
+			Message:  "<app_name> is missing from Portuguese, defined in [English]",
+		}}
+
+		got := lr.MissingTranslationFindings()
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("MissingTranslationFindings() = %+v, want %+v", got, expected)
+		}
+	})
+}
+
+func TestMissingTranslationFindingsByResourceDirectory(t *testing.T) {
+	appPath := filepath.Join("app", "src", "main", "res", "values-pt", "strings.xml")
+	lr := ListResources{
+		{
+			Translation: Translation{Path: filepath.Join("app", "src", "main", "res", "values", "strings.xml"), Language: "English"},
+			Strings:     []String{{Key: "app_only", Value: "App"}, {Key: "shared", Value: "Shared"}},
+		},
+		{
+			Translation: Translation{Path: appPath, LocaleCode: "pt", Language: "Portuguese"},
+			Strings:     []String{{Key: "shared", Value: "Compartilhado"}},
+		},
+		{
+			Translation: Translation{Path: filepath.Join("feature", "src", "main", "res", "values", "strings.xml"), Language: "English"},
+			Strings:     []String{{Key: "shared", Value: "Shared"}},
+		},
+		{
+			Translation: Translation{Path: filepath.Join("feature", "src", "main", "res", "values-pt", "strings.xml"), LocaleCode: "pt", Language: "Portuguese"},
+			Strings:     []String{{Key: "shared", Value: "Compartilhado"}},
+		},
+	}
+
+	expected := []Finding{{
+		RuleID:   RuleMissingTranslation,
+		Severity: SeverityWarning,
+		File:     appPath,
+		Key:      "app_only",
+		Locale:   "pt",
+		Message:  "<app_only> is missing from Portuguese, defined in [English]",
+	}}
+
+	got := lr.MissingTranslationFindings()
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("MissingTranslationFindings() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestMissingTranslationFindingsDefaultAndEnglishLocale(t *testing.T) {
+	englishPath := filepath.Join("res", "values-en", "strings.xml")
+	lr := ListResources{
+		{
+			Translation: Translation{Path: filepath.Join("res", "values", "strings.xml"), LocaleCode: "en", Language: "English"},
+			Strings:     []String{{Key: "a", Value: "A"}, {Key: "b", Value: "B"}},
+		},
+		{
+			Translation: Translation{Path: englishPath, LocaleCode: "en", Language: "English"},
+			Strings:     []String{{Key: "a", Value: "A"}},
+		},
+	}
+
+	expected := []Finding{{
+		RuleID:   RuleMissingTranslation,
+		Severity: SeverityWarning,
+		File:     englishPath,
+		Key:      "b",
+		Locale:   "en",
+		Message:  "<b> is missing from English, defined in [English]",
+	}}
+
+	got := lr.MissingTranslationFindings()
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("MissingTranslationFindings() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestIsSeverityAtLeast(t *testing.T) {
+	tests := []struct {
+		severity  string
+		threshold string
+		expected  bool
+	}{
+		{severity: SeverityError, threshold: SeverityError, expected: true},
+		{severity: SeverityWarning, threshold: SeverityError, expected: false},
+		{severity: SeverityError, threshold: SeverityWarning, expected: true},
+		{severity: SeverityWarning, threshold: SeverityWarning, expected: true},
+		{severity: SeverityInfo, threshold: SeverityWarning, expected: false},
+		{severity: SeverityInfo, threshold: SeverityInfo, expected: true},
+		{severity: SeverityOff, threshold: SeverityInfo, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity+" "+tt.threshold, func(t *testing.T) {
+			if got := IsSeverityAtLeast(tt.severity, tt.threshold); got != tt.expected {
+				t.Errorf("IsSeverityAtLeast(%v, %v) = %v, want %v", tt.severity, tt.threshold, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSortFindings(t *testing.T) {
+	findings := []Finding{
+		{RuleID: RuleMissingTranslation, File: "b.xml", Key: "a"},
+		{RuleID: RuleUnsorted, File: "b.xml", Line: 3},
+		{RuleID: RuleMissingTranslation, File: "a.xml", Key: "b"},
+		{RuleID: RuleUnsorted, File: "b.xml", Line: 1},
+		{RuleID: RuleMissingTranslation, File: "a.xml", Key: "a"},
+	}
+
+	expected := []Finding{
+		{RuleID: RuleUnsorted, File: "b.xml", Line: 1},
+		{RuleID: RuleUnsorted, File: "b.xml", Line: 3},
+		{RuleID: RuleMissingTranslation, File: "a.xml", Key: "a"},
+		{RuleID: RuleMissingTranslation, File: "a.xml", Key: "b"},
+		{RuleID: RuleMissingTranslation, File: "b.xml", Key: "a"},
+	}
+
+	SortFindings(findings)
+	if !reflect.DeepEqual(findings, expected) {
+		t.Errorf("SortFindings() = %+v, want %+v", findings, expected)
+	}
+}
+
+func TestResourceLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strings.xml")
+	os.WriteFile(path, []byte(`<?xml version="1.0" encoding="utf-8"?>
+<!-- Palmeiras -->
+<resources>
+    <string name="a">A</string>
+
+    <plurals name="a">
+        <item quantity="other">As</item>
+    </plurals>
+    <dimen name="margin">8dp</dimen>
+    <string-array name="b"><item>B</item></string-array>
+</resources>
+`), 0o644)
+
+	expected := map[string]int{
+		"a":              4,
+		"plurals/a":      6,
+		"string-array/b": 10,
+	}
+
+	got, err := ResourceLines(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ResourceLines() = %v, want %v", got, expected)
+	}
+}
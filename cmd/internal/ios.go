@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// Language codes Android still uses for its resource directories in place of
+// the ISO 639 codes iOS expects
+var androidLegacyLanguages = map[string]string{"in": "id", "iw": "he", "ji": "yi"}
+
+// Language of a translation in iOS, e.g. pt-BR for values-pt-rBR and id for
+// values-in
+func IOSLanguage(t Translation) string {
+	if code, ok := androidLegacyLanguages[t.LocaleCode]; ok {
+		t.LocaleCode = code
+	}
+
+	return t.LanguageTag()
+}
+
+// Localization directory of a translation in an iOS bundle, e.g. pt-BR.lproj
+func LprojDirectory(t Translation) string {
+	return IOSLanguage(t) + ".lproj"
+}
+
+// Text of a resource value in iOS, with the escaped apostrophes and quotes
+// decoded, the \n escapes as line breaks and the string specifiers of Android
+// as object specifiers, e.g. %1$s as %1$@
+func IOSText(value string) string {
+	return formatSpecifierRegex.ReplaceAllStringFunc(poText(value), func(specifier string) string {
+		if strings.HasSuffix(specifier, "s") || strings.HasSuffix(specifier, "S") {
+			return specifier[:len(specifier)-1] + "@"
+		}
+
+		return specifier
+	})
+}
+
+// Localizable.strings of a locale, with an entry per string and string-array
+// item the locale has a text for. Plurals need a .stringsdict or a String
+// Catalog, so they are left out and their keys are returned.
+func RenderStrings(source, target Resources, notes map[string]string) ([]byte, []string) {
+	var buffer bytes.Buffer
+	buffer.WriteString("/* Exported by polyglot from " + FindingPath(target.Translation.Path) + " */\n")
+
+	plurals := []string{}
+	for _, u := range TextUnits(source, target, notes) {
+		if u.Kind == KindPlurals {
+			if len(plurals) == 0 || plurals[len(plurals)-1] != u.Key {
+				plurals = append(plurals, u.Key)
+			}
+			continue
+		}
+		if !u.HasTarget() {
+			continue
+		}
+
+		buffer.WriteString("\n")
+		if u.Note != "" {
+			buffer.WriteString("/* " + strings.ReplaceAll(u.Note, "*/", "* /") + " */\n")
+		}
+		// The strings of .strings files have the same escapes as the ones of
+		// PO catalogs
+		buffer.WriteString(poQuote(u.ID) + " = " + poQuote(IOSText(u.Target)) + ";\n")
+	}
+
+	return buffer.Bytes(), plurals
+}
+
+type xcstrings struct {
+	SourceLanguage string                    `json:"sourceLanguage"`
+	Strings        map[string]xcstringsEntry `json:"strings"`
+	Version        string                    `json:"version"`
+}
+
+type xcstringsEntry struct {
+	Comment         string                            `json:"comment,omitempty"`
+	ExtractionState string                            `json:"extractionState"`
+	Localizations   map[string]*xcstringsLocalization `json:"localizations"`
+}
+
+type xcstringsLocalization struct {
+	StringUnit *xcstringsStringUnit `json:"stringUnit,omitempty"`
+	Variations *xcstringsVariations `json:"variations,omitempty"`
+}
+
+type xcstringsVariations struct {
+	Plural map[string]xcstringsLocalization `json:"plural"`
+}
+
+type xcstringsStringUnit struct {
+	State string `json:"state"`
+	Value string `json:"value"`
+}
+
+// String Catalog (.xcstrings) with an entry per string, string-array item and
+// plurals of the default locale, in the source language, and its texts in the
+// locales of targets that have them. Plurals become plural variations with the
+// quantities of each locale.
+func RenderXCStrings(source Resources, sourceLanguage Translation, targets ListResources, notes map[string]string) ([]byte, error) {
+	catalog := xcstrings{
+		SourceLanguage: IOSLanguage(sourceLanguage),
+		Strings:        map[string]xcstringsEntry{},
+		Version:        "1.0",
+	}
+
+	localize := func(language string, r Resources, units []TextUnit) {
+		for _, u := range units {
+			id := u.ID
+			if u.Kind == KindPlurals {
+				id = u.Key
+			}
+
+			// Plurals get the quantities of the locale, which may not be the
+			// ones of the default locale
+			localization := &xcstringsLocalization{}
+			switch {
+			case u.Kind == KindPlurals:
+				items := pluralItems(r, u.Key)
+				if len(items) == 0 {
+					continue
+				}
+
+				localization.Variations = &xcstringsVariations{Plural: map[string]xcstringsLocalization{}}
+				for quantity, value := range items {
+					localization.Variations.Plural[quantity] = xcstringsLocalization{
+						StringUnit: &xcstringsStringUnit{State: "translated", Value: IOSText(value)},
+					}
+				}
+			case u.HasTarget():
+				localization.StringUnit = &xcstringsStringUnit{State: "translated", Value: IOSText(u.Target)}
+			default:
+				continue
+			}
+
+			entry, ok := catalog.Strings[id]
+			if !ok {
+				entry = xcstringsEntry{Comment: u.Note, ExtractionState: "manual", Localizations: map[string]*xcstringsLocalization{}}
+			}
+			entry.Localizations[language] = localization
+			catalog.Strings[id] = entry
+		}
+	}
+
+	localize(catalog.SourceLanguage, source, TextUnits(source, source, notes))
+	for _, target := range targets {
+		localize(IOSLanguage(target.Translation), target, TextUnits(source, target, nil))
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(catalog); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,186 @@
+package internal
+
+import (
+	"testing"
+)
+
+func TestIOSText(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"Hello %1$s", "Hello %1$@"},
+		{"%s and %S", "%@ and %@"},
+		{"%d%% of %2$.2f", "%d%% of %2$.2f"},
+		{"100%%s", "100%%s"},
+		{"100% sure", "100% sure"},
+		{"50% off %s", "50% off %@"},
+		{`It\'s \"fine\"`, `It's "fine"`},
+		{`Line\nbreak`, "Line\nbreak"},
+		{"Tom &amp; <b>Jerry</b>", "Tom & <b>Jerry</b>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := IOSText(tt.value); got != tt.expected {
+				t.Errorf("IOSText() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLprojDirectory(t *testing.T) {
+	tests := []struct {
+		translation Translation
+		expected    string
+	}{
+		{Translation{LocaleCode: "en"}, "en.lproj"},
+		{Translation{LocaleCode: "pt", RegionCode: "BR"}, "pt-BR.lproj"},
+		{Translation{LocaleCode: "in"}, "id.lproj"},
+		{Translation{LocaleCode: "iw", RegionCode: "IL"}, "he-IL.lproj"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := LprojDirectory(tt.translation); got != tt.expected {
+				t.Errorf("LprojDirectory() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderStrings(t *testing.T) {
+	source := Resources{
+		Strings: []String{
+			{Key: "greeting", Value: `Hi %1$s, it\'s \"%2$d\"`},
+			{Key: "id", Value: "SEP", Translatable: "false"},
+			{Key: "stadium", Value: "Allianz Parque"},
+		},
+		Plurals:      []Plurals{{Key: "titles", Items: []PluralItem{{Quantity: "one", Value: "%d title"}, {Quantity: "other", Value: "%d titles"}}}},
+		StringArrays: []StringArray{{Key: "players", Items: []StringArrayItem{{Value: "Veiga"}}}},
+	}
+	target := Resources{
+		Translation: Translation{Path: "values-pt-rBR/strings.xml"},
+		Strings:     []String{{Key: "greeting", Value: "Oi %1$s\\nTudo bem?"}},
+	}
+	notes := map[string]string{"greeting": "Shown on */ launch"}
+
+	expected := `/* Exported by polyglot from values-pt-rBR/strings.xml */
+
+/* Shown on * / launch */
+"greeting" = "Oi %1$@\nTudo bem?";
+`
+	got, plurals := RenderStrings(source, target, notes)
+	if string(got) != expected {
+		t.Errorf("RenderStrings() = %v, want %v", string(got), expected)
+	}
+	if len(plurals) != 1 || plurals[0] != "titles" {
+		t.Errorf("RenderStrings() plurals = %v, want [titles]", plurals)
+	}
+
+	source.Translation = Translation{Path: "values/strings.xml"}
+	expected = `/* Exported by polyglot from values/strings.xml */
+
+/* Shown on * / launch */
+"greeting" = "Hi %1$@, it's \"%2$d\"";
+
+"stadium" = "Allianz Parque";
+
+"players:0" = "Veiga";
+`
+	if got, _ := RenderStrings(source, source, notes); string(got) != expected {
+		t.Errorf("RenderStrings() = %v, want %v", string(got), expected)
+	}
+}
+
+func TestRenderXCStrings(t *testing.T) {
+	source := Resources{
+		Strings: []String{{Key: "greeting", Value: "Hi %s"}, {Key: "stadium", Value: "Allianz Parque"}},
+		Plurals: []Plurals{{Key: "titles", Items: []PluralItem{{Quantity: "one", Value: "%d title"}, {Quantity: "other", Value: "%d titles"}}}},
+	}
+	targets := ListResources{{
+		Translation: Translation{LocaleCode: "ru"},
+		Strings:     []String{{Key: "greeting", Value: "Привет %s"}},
+		Plurals:     []Plurals{{Key: "titles", Items: []PluralItem{{Quantity: "few", Value: "%d титула"}}}},
+	}}
+	notes := map[string]string{"greeting": "Home <title>"}
+
+	expected := `{
+  "sourceLanguage": "en",
+  "strings": {
+    "greeting": {
+      "comment": "Home <title>",
+      "extractionState": "manual",
+      "localizations": {
+        "en": {
+          "stringUnit": {
+            "state": "translated",
+            "value": "Hi %@"
+          }
+        },
+        "ru": {
+          "stringUnit": {
+            "state": "translated",
+            "value": "Привет %@"
+          }
+        }
+      }
+    },
+    "stadium": {
+      "extractionState": "manual",
+      "localizations": {
+        "en": {
+          "stringUnit": {
+            "state": "translated",
+            "value": "Allianz Parque"
+          }
+        }
+      }
+    },
+    "titles": {
+      "extractionState": "manual",
+      "localizations": {
+        "en": {
+          "variations": {
+            "plural": {
+              "one": {
+                "stringUnit": {
+                  "state": "translated",
+                  "value": "%d title"
+                }
+              },
+              "other": {
+                "stringUnit": {
+                  "state": "translated",
+                  "value": "%d titles"
+                }
+              }
+            }
+          }
+        },
+        "ru": {
+          "variations": {
+            "plural": {
+              "few": {
+                "stringUnit": {
+                  "state": "translated",
+                  "value": "%d титула"
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  },
+  "version": "1.0"
+}
+`
+	got, err := RenderXCStrings(source, Translation{LocaleCode: "en"}, targets, notes)
+	if err != nil {
+		t.Fatalf("RenderXCStrings() error = %v", err)
+	}
+	if string(got) != expected {
+		t.Errorf("RenderXCStrings() = %v, want %v", string(got), expected)
+	}
+}
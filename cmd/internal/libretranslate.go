@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const ProviderLibreTranslate = "libretranslate"
+
+var (
+	LIBRETRANSLATE_URL     = os.Getenv("LIBRETRANSLATE_URL")
+	LIBRETRANSLATE_API_KEY = os.Getenv("LIBRETRANSLATE_API_KEY")
+)
+
+// Translator backed by a LibreTranslate compatible server, usually self-hosted
+// so no text leaves the company network
+type LibreTranslateTranslator struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewLibreTranslateTranslator(baseURL, apiKey string) (*LibreTranslateTranslator, error) {
+	url := LIBRETRANSLATE_URL
+	if baseURL != "" {
+		url = baseURL
+	}
+
+	if url == "" {
+		return nil, fmt.Errorf("you need to pass the server URL through --libretranslateUrl flag or set the LIBRETRANSLATE_URL environment variable to use LibreTranslate")
+	}
+
+	key := LIBRETRANSLATE_API_KEY
+	if apiKey != "" {
+		key = apiKey
+	}
+
+	return &LibreTranslateTranslator{
+		baseURL: strings.TrimSuffix(url, "/"),
+		apiKey:  key,
+		client:  http.DefaultClient,
+	}, nil
+}
+
+func (l *LibreTranslateTranslator) Name() string {
+	return ProviderLibreTranslate
+}
+
+type libreTranslateRequest struct {
+	Q      []string `json:"q"`
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+	ApiKey string   `json:"api_key,omitempty"`
+}
+
+type libreTranslateResponse struct {
+	TranslatedText []string `json:"translatedText"`
+}
+
+type libreTranslateLanguage struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+func (l *LibreTranslateTranslator) Translate(ctx context.Context, texts []string, source, target Translation) ([]string, error) {
+	request := libreTranslateRequest{
+		Q:      texts,
+		Source: LibreTranslateLanguage(source),
+		Target: LibreTranslateLanguage(target),
+		Format: "text",
+		ApiKey: l.apiKey,
+	}
+
+	var response libreTranslateResponse
+	err := doJSONRequest(ctx, l.client, http.MethodPost, l.baseURL+"/translate", nil, request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.TranslatedText, nil
+}
+
+func (l *LibreTranslateTranslator) SupportedLanguages(ctx context.Context) ([]string, error) {
+	var languages []libreTranslateLanguage
+	err := doJSONRequest(ctx, l.client, http.MethodGet, l.baseURL+"/languages", nil, nil, &languages)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := []string{}
+	for _, language := range languages {
+		codes = append(codes, language.Code)
+	}
+
+	return codes, nil
+}
+
+// Map the locale of a values-xx[-rYY] directory to a LibreTranslate language code
+func LibreTranslateLanguage(t Translation) string {
+	switch locale := strings.ToLower(t.LocaleCode); locale {
+	case "zh":
+		region := strings.ToUpper(t.RegionCode)
+		if region == "TW" || region == "HK" || region == "MO" {
+			return "zt"
+		}
+		return "zh"
+	// Android still uses the deprecated ISO 639 codes for these languages
+	case "iw":
+		return "he"
+	case "in":
+		return "id"
+	case "no":
+		return "nb"
+	default:
+		return locale
+	}
+}
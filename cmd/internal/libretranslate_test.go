@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func newLibreTranslateTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/translate":
+			var request libreTranslateRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Fatalf("Failed to decode request: %v", err)
+			}
+
+			if request.ApiKey != "KEY" {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"error":"Invalid API key"}`))
+				return
+			}
+
+			translated := []string{}
+			for _, q := range request.Q {
+				translated = append(translated, request.Source+">"+request.Target+"|"+request.Format+"|"+q)
+			}
+
+			json.NewEncoder(w).Encode(map[string]any{"translatedText": translated})
+		case "/languages":
+			w.Write([]byte(`[{"code":"en","name":"English"},{"code":"pt","name":"Portuguese"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestNewLibreTranslateTranslator(t *testing.T) {
+	t.Run("Missing URL", func(t *testing.T) {
+		LIBRETRANSLATE_URL = ""
+		defer func() { LIBRETRANSLATE_URL = os.Getenv("LIBRETRANSLATE_URL") }()
+
+		_, err := NewLibreTranslateTranslator("", "")
+		if err == nil {
+			t.Errorf("Expected an error, but got none")
+		}
+	})
+
+	t.Run("Trailing slash is removed", func(t *testing.T) {
+		translator, err := NewLibreTranslateTranslator("http://translate.internal/", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if translator.baseURL != "http://translate.internal" {
+			t.Errorf("baseURL = %v, want %v", translator.baseURL, "http://translate.internal")
+		}
+	})
+}
+
+func TestLibreTranslateTranslatorTranslate(t *testing.T) {
+	server := newLibreTranslateTestServer(t)
+	defer server.Close()
+
+	translator, err := NewLibreTranslateTranslator(server.URL, "KEY")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got, err := translator.Translate(context.Background(), []string{"Hello", "World"}, DefaultSourceTranslation, Translation{LocaleCode: "pt", RegionCode: "BR"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"en>pt|text|Hello", "en>pt|text|World"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Translate() = %v, want %v", got, expected)
+	}
+
+	t.Run("Error status", func(t *testing.T) {
+		translator, _ := NewLibreTranslateTranslator(server.URL, "WRONG")
+
+		_, err := translator.Translate(context.Background(), []string{"Hello"}, DefaultSourceTranslation, Translation{LocaleCode: "pt"})
+		if err == nil {
+			t.Errorf("Expected an error, but got none")
+		}
+	})
+}
+
+func TestLibreTranslateTranslatorSupportedLanguages(t *testing.T) {
+	server := newLibreTranslateTestServer(t)
+	defer server.Close()
+
+	translator, _ := NewLibreTranslateTranslator(server.URL, "")
+
+	got, err := translator.SupportedLanguages(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, []string{"en", "pt"}) {
+		t.Errorf("SupportedLanguages() = %v", got)
+	}
+}
+
+func TestLibreTranslateLanguage(t *testing.T) {
+	tests := []struct {
+		translation Translation
+		expected    string
+	}{
+		{translation: Translation{LocaleCode: "pt", RegionCode: "BR"}, expected: "pt"},
+		{translation: Translation{LocaleCode: "zh"}, expected: "zh"},
+		{translation: Translation{LocaleCode: "zh", RegionCode: "TW"}, expected: "zt"},
+		{translation: Translation{LocaleCode: "iw"}, expected: "he"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got := LibreTranslateLanguage(tt.translation)
+			if got != tt.expected {
+				t.Errorf("LibreTranslateLanguage() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
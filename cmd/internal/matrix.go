@@ -0,0 +1,180 @@
+package internal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Columns of the translation matrix besides the ones of the locales
+const (
+	MatrixKeyColumn      = "key"
+	MatrixChecksumColumn = "checksum"
+)
+
+// Column of a locale in the translation matrix, the name of its values
+// directory, e.g. values-pt-rBR
+func MatrixColumn(t Translation) string {
+	return filepath.Base(filepath.Dir(t.Path))
+}
+
+// Short checksum of the text of a cell, so edits made to the files after the
+// matrix was exported can be told apart from the edits made to the matrix
+func TextChecksum(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:4])
+}
+
+// A row of the translation matrix: a text unit of the default locale with its
+// text in every locale and the checksums of the texts when the matrix was
+// exported, by column
+type MatrixRow struct {
+	ID        string
+	Texts     map[string]string
+	Checksums map[string]string
+}
+
+// CSV with a row per text unit of the default locale and a column per locale,
+// the default locale first. The last column holds the checksums of the texts.
+func (lr ListResources) RenderMatrixCSV() ([]byte, error) {
+	defaultIndex := slices.IndexFunc(lr, func(r Resources) bool { return r.Translation.IsDefault() })
+	if defaultIndex == -1 {
+		return nil, fmt.Errorf("no default values/strings.xml found")
+	}
+
+	locales := ListResources{lr[defaultIndex]}
+	for i, r := range lr {
+		if i != defaultIndex {
+			locales = append(locales, r)
+		}
+	}
+
+	header := []string{MatrixKeyColumn}
+	texts := map[string]map[string]string{}
+	for _, r := range locales {
+		column := MatrixColumn(r.Translation)
+		header = append(header, column)
+
+		texts[column] = map[string]string{}
+		for _, u := range TextUnits(lr[defaultIndex], r, nil) {
+			texts[column][u.ID] = ResourceText(u.Target)
+		}
+	}
+	header = append(header, MatrixChecksumColumn)
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, u := range TextUnits(lr[defaultIndex], lr[defaultIndex], nil) {
+		record := []string{u.ID}
+		checksums := []string{}
+		for _, column := range header[1 : len(header)-1] {
+			record = append(record, texts[column][u.ID])
+			checksums = append(checksums, column+"="+TextChecksum(texts[column][u.ID]))
+		}
+		record = append(record, strings.Join(checksums, " "))
+
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Locale columns and rows of a translation matrix CSV, the columns are in the
+// order of the header
+func ParseMatrixCSV(content []byte) ([]string, []MatrixRow, error) {
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing CSV: %v", err)
+	}
+	if len(records) == 0 || len(records[0]) == 0 || records[0][0] != MatrixKeyColumn {
+		return nil, nil, fmt.Errorf("the CSV has no %v column, export it again with polyglot export --format csv", MatrixKeyColumn)
+	}
+
+	header := records[0]
+	columns := []string{}
+	checksumIndex := -1
+	for i, column := range header[1:] {
+		switch {
+		case column == MatrixChecksumColumn:
+			checksumIndex = i + 1
+		case column == "values" || strings.HasPrefix(column, "values-"):
+			if slices.Contains(columns, column) {
+				return nil, nil, fmt.Errorf("column %v given more than once", column)
+			}
+			columns = append(columns, column)
+		default:
+			return nil, nil, fmt.Errorf("unknown column %q, locale columns are named after their values directory, e.g. values-pt-rBR", column)
+		}
+	}
+
+	rows := []MatrixRow{}
+	for _, record := range records[1:] {
+		row := MatrixRow{ID: record[0], Texts: map[string]string{}, Checksums: map[string]string{}}
+		for i, text := range record[1:] {
+			if header[i+1] != MatrixChecksumColumn {
+				row.Texts[header[i+1]] = text
+			}
+		}
+
+		if checksumIndex != -1 {
+			for _, checksum := range strings.Fields(record[checksumIndex]) {
+				column, sum, _ := strings.Cut(checksum, "=")
+				row.Checksums[column] = sum
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return columns, rows, nil
+}
+
+// Result of comparing a cell of the matrix with the current text of the file
+const (
+	CellUnchanged = "unchanged"
+	CellEdited    = "edited"
+	CellConflict  = "conflict"
+)
+
+// Compare the text of a cell of the row with the current text of the locale.
+// A cell is edited when its text changed since the export and the file did
+// not, and conflicting when both changed. Without a checksum, only cells of
+// texts the file does not have yet can be edited. Empty cells never remove a
+// text.
+func (row MatrixRow) CellChange(column, current string) string {
+	edited := row.Texts[column]
+	if edited == current || edited == "" {
+		return CellUnchanged
+	}
+
+	checksum, ok := row.Checksums[column]
+	switch {
+	case !ok && current == "":
+		return CellEdited
+	case !ok:
+		return CellConflict
+	case checksum == TextChecksum(edited):
+		// Only the file changed since the export, it is kept
+		return CellUnchanged
+	case checksum == TextChecksum(current):
+		return CellEdited
+	default:
+		return CellConflict
+	}
+}
@@ -0,0 +1,102 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenderMatrixCSV(t *testing.T) {
+	lr := ListResources{
+		{
+			Strings:     []String{{Key: "title", Value: "Title"}, {Key: "quote", Value: `The \"Verdão\"`}},
+			Translation: Translation{Path: "res/values-pt-rBR/strings.xml"},
+		},
+		{
+			Strings:      []String{{Key: "title", Value: "Title"}, {Key: "quote", Value: `The "Verdão"`}, {Key: "id", Value: "SEP", Translatable: "false"}},
+			StringArrays: []StringArray{{Key: "planets", Items: []StringArrayItem{{Value: "Mercury"}}}},
+			Translation:  Translation{Path: "res/values/strings.xml"},
+		},
+	}
+
+	got, err := lr.RenderMatrixCSV()
+	if err != nil {
+		t.Fatalf("RenderMatrixCSV() error = %v", err)
+	}
+
+	expected := `key,values,values-pt-rBR,checksum
+title,Title,Title,values=` + TextChecksum("Title") + ` values-pt-rBR=` + TextChecksum("Title") + `
+quote,"The ""Verdão""","The ""Verdão""",values=` + TextChecksum(`The "Verdão"`) + ` values-pt-rBR=` + TextChecksum(`The "Verdão"`) + `
+planets:0,Mercury,,values=` + TextChecksum("Mercury") + ` values-pt-rBR=` + TextChecksum("") + `
+`
+	if string(got) != expected {
+		t.Errorf("RenderMatrixCSV() = %v, want %v", string(got), expected)
+	}
+
+	columns, rows, err := ParseMatrixCSV(got)
+	if err != nil {
+		t.Fatalf("ParseMatrixCSV() error = %v", err)
+	}
+	if !reflect.DeepEqual(columns, []string{"values", "values-pt-rBR"}) {
+		t.Errorf("ParseMatrixCSV() columns = %v", columns)
+	}
+
+	expectedRow := MatrixRow{
+		ID:        "quote",
+		Texts:     map[string]string{"values": `The "Verdão"`, "values-pt-rBR": `The "Verdão"`},
+		Checksums: map[string]string{"values": TextChecksum(`The "Verdão"`), "values-pt-rBR": TextChecksum(`The "Verdão"`)},
+	}
+	if len(rows) != 3 || !reflect.DeepEqual(rows[1], expectedRow) {
+		t.Errorf("ParseMatrixCSV() rows = %+v, want %+v as the second row", rows, expectedRow)
+	}
+}
+
+func TestParseMatrixCSVErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"Without key column", "id,values\na,A\n"},
+		{"Unknown column", "key,values,notes\na,A,B\n"},
+		{"Repeated column", "key,values,values\na,A,B\n"},
+		{"Missing cells", "key,values,values-es\na,A\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ParseMatrixCSV([]byte(tt.content)); err == nil {
+				t.Errorf("ParseMatrixCSV() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestMatrixRowCellChange(t *testing.T) {
+	row := MatrixRow{
+		ID:        "title",
+		Texts:     map[string]string{"values": "Edited", "values-es": "Título", "values-pt": "Novo", "values-fr": ""},
+		Checksums: map[string]string{"values": TextChecksum("Title"), "values-es": TextChecksum("Título"), "values-fr": TextChecksum("Titre")},
+	}
+
+	tests := []struct {
+		name     string
+		column   string
+		current  string
+		expected string
+	}{
+		{"Same as the file", "values-es", "Título", CellUnchanged},
+		{"Edited in the matrix", "values", "Title", CellEdited},
+		{"Edited in the file", "values-es", "Título novo", CellUnchanged},
+		{"Edited in both", "values", "Changed title", CellConflict},
+		{"Emptied cell", "values-fr", "Titre", CellUnchanged},
+		{"New text without checksum", "values-pt", "", CellEdited},
+		{"Changed text without checksum", "values-pt", "Título", CellConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := row.CellChange(tt.column, tt.current); got != tt.expected {
+				t.Errorf("CellChange() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Android format specifiers, e.g. %s, %1$d, %.2f and %%. The space flag of
+// Java is left out, as "50% off" is far more common in copy than "% d".
+var formatSpecifierRegex = regexp.MustCompile(`%(?:\d+\$)?[-#+0,(]*\d*(?:\.\d+)?[a-zA-Z%]`)
+
+// Parts of a string that must reach the translation untouched: <xliff:g> spans,
+// HTML tags, XML entities, format specifiers and backslash escapes. Escaped
+// apostrophes are not masked since they are part of the words around them.
+var placeholderRegex = regexp.MustCompile(`(?s)<xliff:g\b[^>]*>.*?</xliff:g>` +
+	`|</?[a-zA-Z][\w:.-]*(?:\s[^<>]*)?/?>` +
+	`|&(?:#\d+|#x[0-9a-fA-F]+|[a-zA-Z]+);` +
+	`|` + formatSpecifierRegex.String() +
+	`|\\[^']`)
+
+// Token that replaces a placeholder, tolerating the spaces some providers add
+var maskTokenRegex = regexp.MustCompile(`(?i)__\s*PH\s*(\d+)\s*__`)
+
+func maskToken(index int) string {
+	return fmt.Sprintf("__PH%v__", index)
+}
+
+// Replace every placeholder of text by a token the providers leave alone,
+// returning the masked text and the placeholders in the order of the tokens
+func MaskPlaceholders(text string) (string, []string) {
+	placeholders := []string{}
+
+	masked := placeholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+		placeholders = append(placeholders, placeholder)
+		return maskToken(len(placeholders) - 1)
+	})
+
+	// Providers expect plain apostrophes, they are escaped again by RestorePlaceholders
+	masked = strings.ReplaceAll(masked, `\'`, `'`)
+
+	return masked, placeholders
+}
+
+// Put back the placeholders masked by MaskPlaceholders in a translated text.
+// Fails if any placeholder was lost or duplicated by the provider.
+func RestorePlaceholders(translated string, placeholders []string) (string, error) {
+	counts := make([]int, len(placeholders))
+
+	translated = escapeTranslation(translated)
+
+	restored := maskTokenRegex.ReplaceAllStringFunc(translated, func(token string) string {
+		index, err := strconv.Atoi(maskTokenRegex.FindStringSubmatch(token)[1])
+		if err != nil || index >= len(placeholders) {
+			return token
+		}
+
+		counts[index]++
+		return placeholders[index]
+	})
+
+	for index, count := range counts {
+		if count == 0 {
+			return "", fmt.Errorf("placeholder %v was lost in the translation %q", placeholders[index], translated)
+		}
+		if count > 1 {
+			return "", fmt.Errorf("placeholder %v was duplicated in the translation %q", placeholders[index], translated)
+		}
+	}
+
+	if maskTokenRegex.MatchString(restored) {
+		return "", fmt.Errorf("unknown placeholder in the translation %q", translated)
+	}
+
+	return restored, nil
+}
+
+// Escape a translated text as a resource value. Markup was masked before the
+// translation, so what the provider returns is all text. A string quoted as a
+// whole keeps its quotes and apostrophes, which need no escape inside them.
+func escapeTranslation(text string) string {
+	if len(text) >= 2 && strings.HasPrefix(text, `"`) && strings.HasSuffix(text, `"`) {
+		return `"` + strings.ReplaceAll(resourceValue(text[1:len(text)-1], false), `\'`, `'`) + `"`
+	}
+
+	return resourceValue(text, false)
+}
+
+// A format argument consumed by getString(...), numbered by its position
+// when the specifier is not positional
+type FormatArgument struct {
+	Index     int
+	Specifier string
+}
+
+// Conversion character of the argument, e.g. "s" for %1$s
+func (a FormatArgument) Conversion() string {
+	return strings.ToLower(a.Specifier[len(a.Specifier)-1:])
+}
+
+var positionalIndexRegex = regexp.MustCompile(`^%(\d+)\$`)
+
+// Format arguments of an Android string. %% and %n print a literal and take no argument.
+func FormatArguments(value string) []FormatArgument {
+	arguments := []FormatArgument{}
+
+	next := 1
+	for _, specifier := range formatSpecifierRegex.FindAllString(value, -1) {
+		conversion := specifier[len(specifier)-1:]
+		if conversion == "%" || conversion == "n" {
+			continue
+		}
+
+		index := next
+		if match := positionalIndexRegex.FindStringSubmatch(specifier); match != nil {
+			index, _ = strconv.Atoi(match[1])
+		} else {
+			next++
+		}
+
+		arguments = append(arguments, FormatArgument{Index: index, Specifier: specifier})
+	}
+
+	return arguments
+}
+
+func formatArgumentsByIndex(arguments []FormatArgument) map[int]FormatArgument {
+	byIndex := map[int]FormatArgument{}
+	for _, a := range arguments {
+		if _, ok := byIndex[a.Index]; !ok {
+			byIndex[a.Index] = a
+		}
+	}
+	return byIndex
+}
+
+// Compare the format arguments of a translation with the ones of the default
+// string. Extra arguments and arguments with another type crash getString(...)
+// at runtime, missing ones are only reported when allowMissing is false.
+func CompareFormatArguments(source, translated []FormatArgument, allowMissing bool) []string {
+	problems := []string{}
+
+	sourceByIndex := formatArgumentsByIndex(source)
+	translatedByIndex := formatArgumentsByIndex(translated)
+
+	for _, a := range translated {
+		expected, ok := sourceByIndex[a.Index]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("extra %v", a.Specifier))
+			continue
+		}
+
+		if a.Conversion() != expected.Conversion() {
+			problems = append(problems, fmt.Sprintf("wrong type %v (expected %v)", a.Specifier, expected.Specifier))
+		}
+	}
+
+	if !allowMissing {
+		for _, a := range source {
+			if _, ok := translatedByIndex[a.Index]; !ok {
+				problems = append(problems, fmt.Sprintf("missing %v", a.Specifier))
+			}
+		}
+	}
+
+	return slices.Compact(problems)
+}
+
+// A translated resource whose format arguments do not match the default locale
+type PlaceholderMismatch struct {
+	Key      string
+	Path     string
+	Problems []string
+}
+
+// Compare the format arguments of every translated string and plurals with the
+// resource of the same key in the default values/strings.xml of its res directory
+func (lr ListResources) CheckPlaceholders() []PlaceholderMismatch {
+	mismatches := []PlaceholderMismatch{}
+
+	defaults := map[string]Resources{}
+	for _, r := range lr {
+		if r.Translation.IsDefault() {
+			defaults[r.Translation.ResourceDirectory()] = r
+		}
+	}
+
+	for _, r := range lr {
+		source, ok := defaults[r.Translation.ResourceDirectory()]
+		if !ok || r.Translation.IsDefault() {
+			continue
+		}
+
+		for _, s := range r.Strings {
+			index := slices.IndexFunc(source.Strings, func(d String) bool { return d.Key == s.Key })
+			if index < 0 {
+				continue
+			}
+
+			problems := CompareFormatArguments(FormatArguments(source.Strings[index].Value), FormatArguments(s.Value), false)
+			if len(problems) > 0 {
+				mismatches = append(mismatches, PlaceholderMismatch{Key: s.Key, Path: r.Translation.Path, Problems: problems})
+			}
+		}
+
+		for _, p := range r.Plurals {
+			index := slices.IndexFunc(source.Plurals, func(d Plurals) bool { return d.Key == p.Key })
+			if index < 0 {
+				continue
+			}
+
+			// Quantities differ between languages and an item may omit the count,
+			// so items are compared with the arguments of every default item
+			sourceArguments := []FormatArgument{}
+			for _, item := range source.Plurals[index].Items {
+				sourceArguments = append(sourceArguments, FormatArguments(item.Value)...)
+			}
+
+			problems := []string{}
+			for _, item := range p.Items {
+				for _, problem := range CompareFormatArguments(sourceArguments, FormatArguments(item.Value), true) {
+					problems = append(problems, fmt.Sprintf("%v in quantity %v", problem, item.Quantity))
+				}
+			}
+
+			if len(problems) > 0 {
+				mismatches = append(mismatches, PlaceholderMismatch{Key: PluralsReportKey(p.Key), Path: r.Translation.Path, Problems: problems})
+			}
+		}
+	}
+
+	return mismatches
+}
@@ -0,0 +1,282 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMaskPlaceholders(t *testing.T) {
+	tests := []struct {
+		name                 string
+		text                 string
+		expectedMasked       string
+		expectedPlaceholders []string
+	}{
+		{
+			name:                 "Plain text",
+			text:                 "Hello world",
+			expectedMasked:       "Hello world",
+			expectedPlaceholders: []string{},
+		},
+		{
+			name:                 "Positional format arguments",
+			text:                 "Hello %1$s, you have %2$d items",
+			expectedMasked:       "Hello __PH0__, you have __PH1__ items",
+			expectedPlaceholders: []string{"%1$s", "%2$d"},
+		},
+		{
+			name:                 "Simple format arguments and percent sign",
+			text:                 "%s is %.2f%% done",
+			expectedMasked:       "__PH0__ is __PH1____PH2__ done",
+			expectedPlaceholders: []string{"%s", "%.2f", "%%"},
+		},
+		{
+			name:                 "Literal percent signs",
+			text:                 "Save 50% on all items, 100% free",
+			expectedMasked:       "Save 50% on all items, 100% free",
+			expectedPlaceholders: []string{},
+		},
+		{
+			name:                 "Xliff span",
+			text:                 `Hi <xliff:g id="name" example="Bob">%1$s</xliff:g>!`,
+			expectedMasked:       "Hi __PH0__!",
+			expectedPlaceholders: []string{`<xliff:g id="name" example="Bob">%1$s</xliff:g>`},
+		},
+		{
+			name:                 "HTML tags and entities",
+			text:                 "<b>Bold</b> &amp; <a href=\"https://x.io\">link</a><br/>",
+			expectedMasked:       "__PH0__Bold__PH1__ __PH2__ __PH3__link__PH4____PH5__",
+			expectedPlaceholders: []string{"<b>", "</b>", "&amp;", `<a href="https://x.io">`, "</a>", "<br/>"},
+		},
+		{
+			name:                 "Escapes",
+			text:                 `Line one\nLine two\tTab \"quoted\" don\'t`,
+			expectedMasked:       `Line one__PH0__Line two__PH1__Tab __PH2__quoted__PH3__ don't`,
+			expectedPlaceholders: []string{`\n`, `\t`, `\"`, `\"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			masked, placeholders := MaskPlaceholders(tt.text)
+			if masked != tt.expectedMasked {
+				t.Errorf("MaskPlaceholders() masked = %q, want %q", masked, tt.expectedMasked)
+			}
+			if !reflect.DeepEqual(placeholders, tt.expectedPlaceholders) {
+				t.Errorf("MaskPlaceholders() placeholders = %q, want %q", placeholders, tt.expectedPlaceholders)
+			}
+		})
+	}
+}
+
+func TestRestorePlaceholders(t *testing.T) {
+	tests := []struct {
+		name         string
+		translated   string
+		placeholders []string
+		expected     string
+		expectError  bool
+	}{
+		{
+			name:         "Reordered placeholders",
+			translated:   "Você tem __PH1__ itens, __PH0__",
+			placeholders: []string{"%1$s", "%2$d"},
+			expected:     "Você tem %2$d itens, %1$s",
+		},
+		{
+			name:         "Provider added spaces inside tokens",
+			translated:   "Olá __ PH0 __, linha__PH1__",
+			placeholders: []string{"%1$s", `\n`},
+			expected:     `Olá %1$s, linha\n`,
+		},
+		{
+			name:         "Apostrophes are escaped",
+			translated:   "L'application de __PH0__",
+			placeholders: []string{"%1$s"},
+			expected:     `L\'application de %1$s`,
+		},
+		{
+			name:         "Ampersands, angle brackets and quotes are escaped",
+			translated:   `Tom & Jerry's <off> "__PH0__"`,
+			placeholders: []string{"<b>"},
+			expected:     `Tom &amp; Jerry\'s &lt;off> \"<b>\"`,
+		},
+		{
+			name:         "Entities are not escaped twice",
+			translated:   "Tom &amp; Jerry",
+			placeholders: []string{},
+			expected:     "Tom &amp; Jerry",
+		},
+		{
+			name:         "Quoted strings keep apostrophes",
+			translated:   `"L'application"`,
+			placeholders: []string{},
+			expected:     `"L'application"`,
+		},
+		{
+			name:         "Lost placeholder",
+			translated:   "Olá",
+			placeholders: []string{"%1$s"},
+			expectError:  true,
+		},
+		{
+			name:         "Duplicated placeholder",
+			translated:   "__PH0__ __PH0__",
+			placeholders: []string{"%1$s"},
+			expectError:  true,
+		},
+		{
+			name:         "Unknown placeholder",
+			translated:   "__PH0__ __PH3__",
+			placeholders: []string{"%1$s"},
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RestorePlaceholders(tt.translated, tt.placeholders)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("RestorePlaceholders() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTranslateTextsMasksPlaceholders(t *testing.T) {
+	sent := []string{}
+	translator := fakeTranslator{translate: func(texts []string, target Translation) ([]string, error) {
+		sent = append(sent, texts...)
+		return []string{"Olá __PH0__, você tem __PH1__ itens", "Perdido"}, nil
+	}}
+
+	_, err := TranslateTexts(translator, []string{"Hello %1$s, you have %2$d items", "Lost %s"}, DefaultSourceTranslation, Translation{LocaleCode: "pt"})
+	if err == nil {
+		t.Errorf("Expected an error for the lost placeholder, but got none")
+	}
+
+	expectedSent := []string{"Hello __PH0__, you have __PH1__ items", "Lost __PH0__"}
+	if !reflect.DeepEqual(sent, expectedSent) {
+		t.Errorf("Texts sent to the provider = %q, want %q", sent, expectedSent)
+	}
+
+	translator = fakeTranslator{translate: func(texts []string, target Translation) ([]string, error) {
+		return []string{"Olá __PH0__, você tem __PH1__ itens"}, nil
+	}}
+
+	got, err := TranslateText(translator, "Hello %1$s, you have %2$d items", DefaultSourceTranslation, Translation{LocaleCode: "pt"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got != "Olá %1$s, você tem %2$d itens" {
+		t.Errorf("TranslateText() = %q", got)
+	}
+}
+
+func TestFormatArguments(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []FormatArgument
+	}{
+		{name: "No arguments", value: "Hello 100%% sure", expected: []FormatArgument{}},
+		{name: "Literal percent signs", value: "50% off, 100% free", expected: []FormatArgument{}},
+		{
+			name:     "Positional arguments",
+			value:    "Hello %2$s, you have %1$d items",
+			expected: []FormatArgument{{Index: 2, Specifier: "%2$s"}, {Index: 1, Specifier: "%1$d"}},
+		},
+		{
+			name:     "Non-positional arguments",
+			value:    "%s has %.1f%% of %d%n",
+			expected: []FormatArgument{{Index: 1, Specifier: "%s"}, {Index: 2, Specifier: "%.1f"}, {Index: 3, Specifier: "%d"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatArguments(tt.value)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("FormatArguments() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompareFormatArguments(t *testing.T) {
+	tests := []struct {
+		name         string
+		source       string
+		translated   string
+		allowMissing bool
+		expected     []string
+	}{
+		{name: "Same arguments reordered", source: "%1$s has %2$d", translated: "%2$d para %1$s", expected: []string{}},
+		{name: "Positional and non-positional", source: "%s has %d", translated: "%1$s tem %2$d", expected: []string{}},
+		{name: "Missing argument", source: "Hello %1$s", translated: "Olá", expected: []string{"missing %1$s"}},
+		{name: "Missing argument allowed", source: "%d songs", translated: "Uma música", allowMissing: true, expected: []string{}},
+		{name: "Wrong type", source: "%1$s items", translated: "%1$d itens", expected: []string{"wrong type %1$d (expected %1$s)"}},
+		{name: "Extra argument", source: "Hello %1$s", translated: "Olá %1$s %2$s", expected: []string{"extra %2$s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompareFormatArguments(FormatArguments(tt.source), FormatArguments(tt.translated), tt.allowMissing)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("CompareFormatArguments() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckPlaceholders(t *testing.T) {
+	listResources := ListResources{
+		{
+			Translation: Translation{Path: "app/res/values/strings.xml"},
+			Strings: []String{
+				{Key: "greeting", Value: "Hello %1$s"},
+				{Key: "items", Value: "%1$d items"},
+				{Key: "sale", Value: "50% off"},
+			},
+			Plurals: []Plurals{
+				{Key: "songs", Items: []PluralItem{{Quantity: "one", Value: "One song"}, {Quantity: "other", Value: "%d songs"}}},
+			},
+		},
+		{
+			Translation: Translation{Path: "app/res/values-pt/strings.xml"},
+			Strings: []String{
+				{Key: "greeting", Value: "Olá %1$s"},
+				{Key: "items", Value: "%1$s itens"},
+				{Key: "sale", Value: "50% de desconto"},
+				{Key: "only_here", Value: "%5$s"},
+			},
+			Plurals: []Plurals{
+				{Key: "songs", Items: []PluralItem{{Quantity: "one", Value: "%d música"}, {Quantity: "other", Value: "%s músicas"}}},
+			},
+		},
+		{
+			Translation: Translation{Path: "feature/res/values-pt/strings.xml"},
+			Strings:     []String{{Key: "greeting", Value: "Olá"}},
+		},
+	}
+
+	expected := []PlaceholderMismatch{
+		{Key: "items", Path: "app/res/values-pt/strings.xml", Problems: []string{"wrong type %1$s (expected %1$d)"}},
+		{Key: "plurals/songs", Path: "app/res/values-pt/strings.xml", Problems: []string{"wrong type %s (expected %d) in quantity other"}},
+	}
+
+	got := listResources.CheckPlaceholders()
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("CheckPlaceholders() = %v, want %v", got, expected)
+	}
+}
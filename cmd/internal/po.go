@@ -0,0 +1,424 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// State of the units of fuzzy entries of a PO catalog, translations that
+// still need a review
+const POStateFuzzy = "fuzzy"
+
+// Plural forms of gettext and the Android quantity of each form, in the order
+// of the forms
+type poPlurals struct {
+	expression string
+	quantities []string
+}
+
+var defaultPOPlurals = poPlurals{"nplurals=2; plural=(n != 1);", []string{QuantityOne, QuantityOther}}
+
+// Plural forms of the languages whose rules differ from the default one and
+// other, by language or language tag, as listed by gettext
+var poPluralForms = map[string]poPlurals{
+	"ja": {"nplurals=1; plural=0;", []string{QuantityOther}},
+	"ko": {"nplurals=1; plural=0;", []string{QuantityOther}},
+	"zh": {"nplurals=1; plural=0;", []string{QuantityOther}},
+	"vi": {"nplurals=1; plural=0;", []string{QuantityOther}},
+	"th": {"nplurals=1; plural=0;", []string{QuantityOther}},
+	"id": {"nplurals=1; plural=0;", []string{QuantityOther}},
+	"ms": {"nplurals=1; plural=0;", []string{QuantityOther}},
+
+	"fr":    {"nplurals=2; plural=(n > 1);", []string{QuantityOne, QuantityOther}},
+	"pt":    {"nplurals=2; plural=(n > 1);", []string{QuantityOne, QuantityOther}},
+	"pt-PT": defaultPOPlurals,
+
+	"ru": {"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{QuantityOne, QuantityFew, QuantityMany}},
+	"uk": {"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{QuantityOne, QuantityFew, QuantityMany}},
+	"be": {"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{QuantityOne, QuantityFew, QuantityMany}},
+	"hr": {"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{QuantityOne, QuantityFew, QuantityOther}},
+	"sr": {"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{QuantityOne, QuantityFew, QuantityOther}},
+	"bs": {"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{QuantityOne, QuantityFew, QuantityOther}},
+	"pl": {"nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{QuantityOne, QuantityFew, QuantityMany}},
+	"cs": {"nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;", []string{QuantityOne, QuantityFew, QuantityOther}},
+	"sk": {"nplurals=3; plural=(n==1) ? 0 : (n>=2 && n<=4) ? 1 : 2;", []string{QuantityOne, QuantityFew, QuantityOther}},
+	"lt": {"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && (n%100<10 || n%100>=20) ? 1 : 2);", []string{QuantityOne, QuantityFew, QuantityOther}},
+	"lv": {"nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n != 0 ? 1 : 2);", []string{QuantityOne, QuantityOther, QuantityZero}},
+	"ro": {"nplurals=3; plural=(n==1 ? 0 : (n==0 || (n%100 > 0 && n%100 < 20)) ? 1 : 2);", []string{QuantityOne, QuantityFew, QuantityOther}},
+	"sl": {"nplurals=4; plural=(n%100==1 ? 0 : n%100==2 ? 1 : n%100==3 || n%100==4 ? 2 : 3);", []string{QuantityOne, QuantityTwo, QuantityFew, QuantityOther}},
+	"ar": {"nplurals=6; plural=(n==0 ? 0 : n==1 ? 1 : n==2 ? 2 : n%100>=3 && n%100<=10 ? 3 : n%100>=11 ? 4 : 5);", []string{QuantityZero, QuantityOne, QuantityTwo, QuantityFew, QuantityMany, QuantityOther}},
+}
+
+// Quantities of the form at index i. Android requires other in every plurals,
+// so the last form also gives other to languages whose forms have none.
+func (p poPlurals) formQuantities(i int) []string {
+	quantities := []string{p.quantities[i]}
+	if i == len(p.quantities)-1 && !slices.Contains(p.quantities, QuantityOther) {
+		quantities = append(quantities, QuantityOther)
+	}
+
+	return quantities
+}
+
+func pluralsOf(t Translation) poPlurals {
+	if plurals, ok := poPluralForms[t.LanguageTag()]; ok {
+		return plurals
+	}
+	if plurals, ok := poPluralForms[t.LocaleCode]; ok {
+		return plurals
+	}
+
+	return defaultPOPlurals
+}
+
+// Language of a translation as written in PO catalogs, e.g. pt_BR
+func POLanguage(t Translation) string {
+	return strings.ReplaceAll(t.LanguageTag(), "-", "_")
+}
+
+// POT template of the default locale, with an entry per string and
+// string-array item and a plural entry per plurals
+func RenderPOT(source Resources, notes map[string]string) []byte {
+	return renderPO(source, Resources{}, notes, nil)
+}
+
+// PO catalog of the target locale, with the texts it already has
+func RenderPO(source, target Resources, notes map[string]string, t Translation) []byte {
+	return renderPO(source, target, notes, &t)
+}
+
+// Entries are identified by msgctxt, the unit ID for strings and string-array
+// items and the key for plurals, whose forms are the quantities of the
+// language
+func renderPO(source, target Resources, notes map[string]string, t *Translation) []byte {
+	var buffer bytes.Buffer
+
+	language, pluralForms := "", "nplurals=INTEGER; plural=EXPRESSION;"
+	plurals := defaultPOPlurals
+	if t != nil {
+		plurals = pluralsOf(*t)
+		language, pluralForms = POLanguage(*t), plurals.expression
+	}
+
+	buffer.WriteString("msgid \"\"\nmsgstr \"\"\n")
+	for _, header := range []string{
+		"Language: " + language,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: 8bit",
+		"Plural-Forms: " + pluralForms,
+		"X-Generator: polyglot",
+	} {
+		buffer.WriteString(poQuote(header+"\n") + "\n")
+	}
+
+	written := []string{}
+	for _, u := range TextUnits(source, target, notes) {
+		if slices.Contains(written, u.Key) && u.Kind == KindPlurals {
+			continue
+		}
+
+		buffer.WriteString("\n")
+		if u.Note != "" {
+			for _, line := range strings.Split(u.Note, "\n") {
+				buffer.WriteString("#. " + strings.TrimSpace(line) + "\n")
+			}
+		}
+
+		if u.Kind != KindPlurals {
+			buffer.WriteString(poField("msgctxt", u.ID))
+			buffer.WriteString(poField("msgid", poText(u.Source)))
+			buffer.WriteString(poField("msgstr", poText(u.Target)))
+			continue
+		}
+
+		written = append(written, u.Key)
+		sourceItems := pluralItems(source, u.Key)
+		targetItems := pluralItems(target, u.Key)
+
+		buffer.WriteString(poField("msgctxt", u.Key))
+		buffer.WriteString(poField("msgid", poText(firstOf(sourceItems, QuantityOne, QuantityOther))))
+		buffer.WriteString(poField("msgid_plural", poText(firstOf(sourceItems, QuantityOther, QuantityOne))))
+		for i := range plurals.quantities {
+			value := ""
+			for _, quantity := range plurals.formQuantities(i) {
+				if v, ok := targetItems[quantity]; ok {
+					value = v
+					break
+				}
+			}
+			buffer.WriteString(poField(fmt.Sprintf("msgstr[%v]", i), poText(value)))
+		}
+	}
+
+	return buffer.Bytes()
+}
+
+// Values of the items of the plurals with key by quantity
+func pluralItems(r Resources, key string) map[string]string {
+	items := map[string]string{}
+	for _, p := range r.Plurals {
+		if p.Key == key {
+			for _, item := range p.Items {
+				items[item.Quantity] = item.Value
+			}
+		}
+	}
+
+	return items
+}
+
+// Value of the first of the quantities the items have
+func firstOf(items map[string]string, quantities ...string) string {
+	for _, quantity := range quantities {
+		if value, ok := items[quantity]; ok {
+			return value
+		}
+	}
+
+	for _, quantity := range PluralQuantities {
+		if value, ok := items[quantity]; ok {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// Text of a resource value in a PO catalog, with the \n escapes of Android as
+// line breaks
+func poText(value string) string {
+	return strings.ReplaceAll(ResourceText(value), `\n`, "\n")
+}
+
+// Resource value of a text of a PO catalog, the inverse of poText
+func poValue(text string) string {
+	return ResourceValue(strings.ReplaceAll(text, "\n", `\n`))
+}
+
+// Keyword with its string, split after each line break as gettext does
+func poField(keyword, text string) string {
+	lines := strings.SplitAfter(text, "\n")
+	if len(lines) == 1 || len(lines) == 2 && lines[1] == "" {
+		return keyword + " " + poQuote(text) + "\n"
+	}
+
+	field := keyword + " \"\"\n"
+	for _, line := range lines {
+		if line != "" {
+			field += poQuote(line) + "\n"
+		}
+	}
+
+	return field
+}
+
+var poQuoteReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+func poQuote(text string) string {
+	return `"` + poQuoteReplacer.Replace(text) + `"`
+}
+
+var poUnquoteReplacer = strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\n`, "\n", `\t`, "\t", `\r`, "\r")
+
+func poUnquote(quoted string) (string, error) {
+	quoted = strings.TrimSpace(quoted)
+	if len(quoted) < 2 || !strings.HasPrefix(quoted, `"`) || !strings.HasSuffix(quoted, `"`) {
+		return "", fmt.Errorf("invalid string %v", quoted)
+	}
+
+	return poUnquoteReplacer.Replace(quoted[1 : len(quoted)-1]), nil
+}
+
+// Language and units of a PO catalog. Units of fuzzy entries have the fuzzy
+// state, the translations of plural entries become a unit per quantity.
+type PODocument struct {
+	Language string
+	Units    []TextUnit
+}
+
+type poEntry struct {
+	flags    []string
+	context  *string
+	id       string
+	idPlural *string
+	strs     map[int]*string
+}
+
+var (
+	poKeywordRegex  = regexp.MustCompile(`^(msgctxt|msgid_plural|msgid|msgstr(?:\[(\d+)\])?)\s+(".*")$`)
+	poNPluralsRegex = regexp.MustCompile(`nplurals\s*=\s*(\d+)`)
+)
+
+// Parse a PO catalog. Entries without msgctxt keep their msgid as the unit ID
+// and obsolete entries are ignored.
+func ParsePO(content []byte) (PODocument, error) {
+	entries, err := parsePOEntries(content)
+	if err != nil {
+		return PODocument{}, err
+	}
+
+	document := PODocument{Units: []TextUnit{}}
+	nplurals := -1
+	for _, e := range entries {
+		if e.context == nil && e.id == "" && e.strs[0] != nil {
+			for _, line := range strings.Split(*e.strs[0], "\n") {
+				name, value, _ := strings.Cut(line, ":")
+				switch strings.TrimSpace(name) {
+				case "Language":
+					document.Language = strings.TrimSpace(value)
+				case "Plural-Forms":
+					if matches := poNPluralsRegex.FindStringSubmatch(value); matches != nil {
+						nplurals, _ = strconv.Atoi(matches[1])
+					}
+				}
+			}
+		}
+	}
+
+	if document.Language == "" {
+		return PODocument{}, fmt.Errorf("the PO catalog has no Language header")
+	}
+
+	t, err := TranslationFromLanguageTag(document.Language)
+	if err != nil {
+		return PODocument{}, err
+	}
+	plurals := pluralsOf(t)
+
+	for _, e := range entries {
+		if e.context == nil && e.id == "" {
+			continue
+		}
+
+		state := ""
+		if slices.Contains(e.flags, "fuzzy") {
+			state = POStateFuzzy
+		}
+
+		id := e.id
+		if e.context != nil {
+			id = *e.context
+		}
+
+		if e.idPlural == nil {
+			u := parsedUnit(id, "")
+			u.Source = poValue(e.id)
+			if e.strs[0] != nil {
+				u.Target = poValue(*e.strs[0])
+			}
+			u.State = state
+			document.Units = append(document.Units, u)
+			continue
+		}
+
+		if nplurals == -1 {
+			return PODocument{}, fmt.Errorf("the PO catalog has plural entries but no Plural-Forms header, %v has %v forms: %v", document.Language, len(plurals.quantities), plurals.expression)
+		}
+		if nplurals != len(plurals.quantities) {
+			return PODocument{}, fmt.Errorf("the Plural-Forms of the PO catalog have %v forms, %v has %v: %v", nplurals, document.Language, len(plurals.quantities), plurals.expression)
+		}
+
+		for i := range plurals.quantities {
+			for _, quantity := range plurals.formQuantities(i) {
+				u := TextUnit{ID: unitID(id, quantity), Kind: KindPlurals, Key: id, Selector: quantity, Source: poValue(*e.idPlural), State: state}
+				if quantity == QuantityOne {
+					u.Source = poValue(e.id)
+				}
+				if e.strs[i] != nil {
+					u.Target = poValue(*e.strs[i])
+				}
+				document.Units = append(document.Units, u)
+			}
+		}
+	}
+
+	return document, nil
+}
+
+func parsePOEntries(content []byte) ([]*poEntry, error) {
+	entries := []*poEntry{}
+	var entry *poEntry
+	var field *string
+
+	// An entry starts at its comments or its first keyword, after the msgstr
+	// of the previous entry
+	startEntry := func() {
+		if entry == nil || len(entry.strs) > 0 {
+			entry = &poEntry{strs: map[int]*string{}}
+			entries = append(entries, entry)
+		}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#~"):
+			field = nil
+		case strings.HasPrefix(line, "#"):
+			startEntry()
+			field = nil
+			if flags, ok := strings.CutPrefix(line, "#,"); ok {
+				for _, flag := range strings.Split(flags, ",") {
+					entry.flags = append(entry.flags, strings.TrimSpace(flag))
+				}
+			}
+		case strings.HasPrefix(line, `"`):
+			if field == nil {
+				return nil, fmt.Errorf("line %v: string without a keyword", number)
+			}
+			text, err := poUnquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %v: %v", number, err)
+			}
+			*field += text
+		default:
+			matches := poKeywordRegex.FindStringSubmatch(line)
+			if matches == nil {
+				return nil, fmt.Errorf("line %v: unknown keyword in %q", number, line)
+			}
+
+			text, err := poUnquote(matches[3])
+			if err != nil {
+				return nil, fmt.Errorf("line %v: %v", number, err)
+			}
+
+			keyword := matches[1]
+			if keyword == "msgctxt" || keyword == "msgid" {
+				startEntry()
+			}
+			if entry == nil {
+				return nil, fmt.Errorf("line %v: %v without msgid", number, keyword)
+			}
+
+			switch keyword {
+			case "msgctxt":
+				entry.context = &text
+				field = entry.context
+			case "msgid":
+				entry.id = text
+				field = &entry.id
+			case "msgid_plural":
+				entry.idPlural = &text
+				field = entry.idPlural
+			default:
+				index, _ := strconv.Atoi(matches[2])
+				entry.strs[index] = &text
+				field = &text
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
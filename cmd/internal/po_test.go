@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderPO(t *testing.T) {
+	source := Resources{
+		Strings: []String{
+			{Key: "title", Value: `Palmeiras\' club\nSince 1914`},
+			{Key: "id", Value: "SEP", Translatable: "false"},
+		},
+		Plurals:      []Plurals{{Key: "titles", Items: []PluralItem{{Quantity: "one", Value: "%d title"}, {Quantity: "other", Value: "%d titles"}}}},
+		StringArrays: []StringArray{{Key: "players", Items: []StringArrayItem{{Value: `Raphael \"Veiga\"`}}}},
+	}
+	target := Resources{
+		Plurals: []Plurals{{Key: "titles", Items: []PluralItem{{Quantity: "one", Value: "%d титул"}, {Quantity: "many", Value: "%d титулов"}}}},
+	}
+	notes := map[string]string{"title": "Home title"}
+
+	expectedPOT := `msgid ""
+msgstr ""
+"Language: \n"
+"MIME-Version: 1.0\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+"Content-Transfer-Encoding: 8bit\n"
+"Plural-Forms: nplurals=INTEGER; plural=EXPRESSION;\n"
+"X-Generator: polyglot\n"
+
+#. Home title
+msgctxt "title"
+msgid ""
+"Palmeiras' club\n"
+"Since 1914"
+msgstr ""
+
+msgctxt "titles"
+msgid "%d title"
+msgid_plural "%d titles"
+msgstr[0] ""
+msgstr[1] ""
+
+msgctxt "players:0"
+msgid "Raphael \"Veiga\""
+msgstr ""
+`
+	if got := string(RenderPOT(source, notes)); got != expectedPOT {
+		t.Errorf("RenderPOT() = %v, want %v", got, expectedPOT)
+	}
+
+	expectedPO := `msgid ""
+msgstr ""
+"Language: ru\n"
+"MIME-Version: 1.0\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+"Content-Transfer-Encoding: 8bit\n"
+"Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+"X-Generator: polyglot\n"
+
+#. Home title
+msgctxt "title"
+msgid ""
+"Palmeiras' club\n"
+"Since 1914"
+msgstr ""
+
+msgctxt "titles"
+msgid "%d title"
+msgid_plural "%d titles"
+msgstr[0] "%d титул"
+msgstr[1] ""
+msgstr[2] "%d титулов"
+
+msgctxt "players:0"
+msgid "Raphael \"Veiga\""
+msgstr ""
+`
+	if got := string(RenderPO(source, target, notes, Translation{LocaleCode: "ru"})); got != expectedPO {
+		t.Errorf("RenderPO() = %v, want %v", got, expectedPO)
+	}
+}
+
+func TestParsePO(t *testing.T) {
+	content := `# Translators of the club
+msgid ""
+msgstr ""
+"Language: pt_BR\n"
+"Plural-Forms: nplurals=2; plural=(n > 1);\n"
+
+#. Home title
+msgctxt "title"
+msgid ""
+"Palmeiras' club\n"
+"Since 1914"
+msgstr ""
+"Clube do Palmeiras\n"
+"Desde 1914"
+
+#, fuzzy, c-format
+msgctxt "players:0"
+msgid "Raphael \"Veiga\""
+msgstr "Raphael \"Veiga\""
+
+msgctxt "titles"
+msgid "%d title"
+msgid_plural "%d titles"
+msgstr[0] "%d título"
+msgstr[1] ""
+
+msgid "Without context"
+msgstr "Sem contexto"
+
+#~ msgctxt "old"
+#~ msgid "Old"
+#~ msgstr "Antigo"
+`
+
+	expected := PODocument{Language: "pt_BR", Units: []TextUnit{
+		{ID: "title", Kind: KindString, Key: "title", Source: `Palmeiras\' club\nSince 1914`, Target: `Clube do Palmeiras\nDesde 1914`},
+		{ID: "players:0", Kind: KindStringArray, Key: "players", Selector: "0", Source: `Raphael \"Veiga\"`, Target: `Raphael \"Veiga\"`, State: POStateFuzzy},
+		{ID: "titles:one", Kind: KindPlurals, Key: "titles", Selector: "one", Source: "%d title", Target: "%d título"},
+		{ID: "titles:other", Kind: KindPlurals, Key: "titles", Selector: "other", Source: "%d titles"},
+		{ID: "Without context", Source: "Without context", Target: "Sem contexto"},
+	}}
+
+	got, err := ParsePO([]byte(content))
+	if err != nil {
+		t.Fatalf("ParsePO() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ParsePO() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestParsePOErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"Without language", "msgid \"\"\nmsgstr \"\"\n\"Plural-Forms: nplurals=2; plural=(n != 1);\\n\"\n"},
+		{"Unknown keyword", "msgid \"\"\nmsgstr \"Language: es\\n\"\n\nmsgkey \"a\"\n"},
+		{"Unquoted string", "msgid \"\"\nmsgstr \"Language: es\\n\"\n\nmsgid a\n"},
+		{"Different plural forms", "msgid \"\"\nmsgstr \"Language: ru\\nPlural-Forms: nplurals=2; plural=(n != 1);\\n\"\n\nmsgctxt \"a\"\nmsgid \"a\"\nmsgid_plural \"a\"\nmsgstr[0] \"a\"\nmsgstr[1] \"a\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePO([]byte(tt.content)); err == nil {
+				t.Errorf("ParsePO() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestParsePOWithoutPluralForms(t *testing.T) {
+	content := "msgid \"\"\nmsgstr \"Language: ru\\n\"\n\nmsgctxt \"a\"\nmsgid \"a\"\nmsgid_plural \"a\"\nmsgstr[0] \"a\"\n"
+
+	_, err := ParsePO([]byte(content))
+	if err == nil || !strings.Contains(err.Error(), "no Plural-Forms header") {
+		t.Errorf("ParsePO() error = %v, want the missing Plural-Forms header", err)
+	}
+
+	// Catalogs without plural entries do not need the header
+	if _, err := ParsePO([]byte("msgid \"\"\nmsgstr \"Language: ru\\n\"\n\nmsgid \"a\"\nmsgstr \"b\"\n")); err != nil {
+		t.Errorf("ParsePO() error = %v, want none", err)
+	}
+}
@@ -0,0 +1,174 @@
+package internal
+
+import "strings"
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIF 2.1.0 log with the findings of a check, the format accepted by code
+// scanning tools to annotate files
+type SarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []SarifRule `json:"rules"`
+}
+
+type SarifRule struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     SarifMessage           `json:"shortDescription"`
+	DefaultConfiguration SarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type SarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifResult struct {
+	RuleID        string            `json:"ruleId"`
+	Level         string            `json:"level"`
+	Message       SarifMessage      `json:"message"`
+	Locations     []SarifLocation   `json:"locations,omitempty"`
+	BaselineState string            `json:"baselineState,omitempty"`
+	Properties    map[string]string `json:"properties,omitempty"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           *SarifRegion          `json:"region,omitempty"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// SARIF levels are error, warning, note and none
+func sarifLevel(severity string) string {
+	switch severity {
+	case SeverityInfo:
+		return "note"
+	case SeverityOff:
+		return "none"
+	}
+
+	return severity
+}
+
+// Location of a finding in its file, none for findings without a file
+func sarifLocations(file string, line int) []SarifLocation {
+	if file == "" {
+		return nil
+	}
+
+	location := SarifLocation{PhysicalLocation: SarifPhysicalLocation{
+		ArtifactLocation: SarifArtifactLocation{URI: strings.TrimPrefix(file, "./")},
+	}}
+	if line > 0 {
+		location.PhysicalLocation.Region = &SarifRegion{StartLine: line}
+	}
+
+	return []SarifLocation{location}
+}
+
+func sarifProperties(key, locale string) map[string]string {
+	properties := map[string]string{}
+	if key != "" {
+		properties["key"] = key
+	}
+	if locale != "" {
+		properties["locale"] = locale
+	}
+
+	if len(properties) == 0 {
+		return nil
+	}
+
+	return properties
+}
+
+// Report of the findings, with the level of each rule given by severity
+func NewSarifReport(findings []Finding, severity func(rule string) string) SarifReport {
+	rules := []SarifRule{}
+	for _, rule := range CheckRules() {
+		rules = append(rules, SarifRule{
+			ID:                   rule,
+			ShortDescription:     SarifMessage{Text: RuleDescriptions[rule]},
+			DefaultConfiguration: SarifRuleConfiguration{Level: sarifLevel(severity(rule))},
+		})
+	}
+
+	results := []SarifResult{}
+	for _, f := range findings {
+		results = append(results, SarifResult{
+			RuleID:     f.RuleID,
+			Level:      sarifLevel(f.Severity),
+			Message:    SarifMessage{Text: f.Message},
+			Locations:  sarifLocations(f.File, f.Line),
+			Properties: sarifProperties(f.Key, f.Locale),
+		})
+	}
+
+	return SarifReport{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SarifRun{{
+			Tool: SarifTool{Driver: SarifDriver{
+				Name:           "polyglot",
+				InformationURI: "https://github.com/gustoliveira/polyglot",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// Mark the results of the report as new to the baseline and add the fixed
+// entries of the baseline as absent results
+func (s SarifReport) WithBaseline(fixed []BaselineEntry) SarifReport {
+	for i := range s.Runs {
+		for j := range s.Runs[i].Results {
+			s.Runs[i].Results[j].BaselineState = "new"
+		}
+
+		for _, e := range fixed {
+			s.Runs[i].Results = append(s.Runs[i].Results, SarifResult{
+				RuleID:        e.RuleID,
+				Level:         "none",
+				Message:       SarifMessage{Text: e.Message},
+				Locations:     sarifLocations(e.File, 0),
+				BaselineState: "absent",
+				Properties:    sarifProperties(e.Key, e.Locale),
+			})
+		}
+	}
+
+	return s
+}
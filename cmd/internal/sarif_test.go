@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewSarifReport(t *testing.T) {
+	findings := []Finding{
+		{RuleID: RulePlaceholderMismatch, Severity: SeverityError, File: "values-pt/strings.xml", Line: 3, Key: "welcome", Locale: "pt", Message: "<welcome> missing %1$s"},
+		{RuleID: RuleMissingTranslation, Severity: SeverityInfo, File: "values-es/strings.xml", Key: "app_name", Locale: "es", Message: "<app_name> is missing"},
+	}
+
+	report := NewSarifReport(findings, DefaultSeverity)
+
+	if report.Version != "2.1.0" || len(report.Runs) != 1 {
+		t.Fatalf("NewSarifReport() = %+v, want a single 2.1.0 run", report)
+	}
+
+	if len(report.Runs[0].Tool.Driver.Rules) != len(CheckRules()) {
+		t.Errorf("NewSarifReport() rules = %v, want %v", len(report.Runs[0].Tool.Driver.Rules), len(CheckRules()))
+	}
+
+	expected := []SarifResult{
+		{
+			RuleID:  RulePlaceholderMismatch,
+			Level:   "error",
+			Message: SarifMessage{Text: "<welcome> missing %1$s"},
+			Locations: []SarifLocation{{PhysicalLocation: SarifPhysicalLocation{
+				ArtifactLocation: SarifArtifactLocation{URI: "values-pt/strings.xml"},
+				Region:           &SarifRegion{StartLine: 3},
+			}}},
+			Properties: map[string]string{"key": "welcome", "locale": "pt"},
+		},
+		{
+			RuleID:  RuleMissingTranslation,
+			Level:   "note",
+			Message: SarifMessage{Text: "<app_name> is missing"},
+			Locations: []SarifLocation{{PhysicalLocation: SarifPhysicalLocation{
+				ArtifactLocation: SarifArtifactLocation{URI: "values-es/strings.xml"},
+			}}},
+			Properties: map[string]string{"key": "app_name", "locale": "es"},
+		},
+	}
+
+	if !reflect.DeepEqual(report.Runs[0].Results, expected) {
+		t.Errorf("NewSarifReport() results = %+v, want %+v", report.Runs[0].Results, expected)
+	}
+}
+
+func TestSarifReportWithBaseline(t *testing.T) {
+	findings := []Finding{
+		{RuleID: RuleUnsorted, Severity: SeverityWarning, File: "values/strings.xml", Line: 2, Key: "a", Locale: "en", Message: "unsorted"},
+	}
+	fixed := []BaselineEntry{
+		{RuleID: RuleMissingTranslation, File: "values-pt/strings.xml", Key: "b", Locale: "pt", Message: "missing b"},
+	}
+
+	results := NewSarifReport(findings, DefaultSeverity).WithBaseline(fixed).Runs[0].Results
+
+	if len(results) != 2 {
+		t.Fatalf("WithBaseline() results = %+v, want 2 results", results)
+	}
+	if results[0].BaselineState != "new" {
+		t.Errorf("WithBaseline() finding state = %v, want new", results[0].BaselineState)
+	}
+	if results[1].BaselineState != "absent" || results[1].Level != "none" || results[1].RuleID != RuleMissingTranslation {
+		t.Errorf("WithBaseline() fixed result = %+v, want an absent result", results[1])
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"cloud.google.com/go/translate"
 	"golang.org/x/text/language"
@@ -19,42 +20,141 @@ type Translation struct {
 	RegionCode string
 }
 
+// Check if the translation is the default values/strings.xml of a resource directory
+func (t Translation) IsDefault() bool {
+	return filepath.Base(filepath.Dir(t.Path)) == "values"
+}
+
+// Resource qualifier of the translation directory, e.g. "pt-rBR" for values-pt-rBR
+// and empty for the default values directory
+func (t Translation) Qualifier() string {
+	if t.IsDefault() {
+		return ""
+	}
+
+	if t.RegionCode != "" {
+		return t.LocaleCode + "-r" + t.RegionCode
+	}
+
+	return t.LocaleCode
+}
+
+// BCP 47 language tag of the translation, e.g. pt-BR for values-pt-rBR
+func (t Translation) LanguageTag() string {
+	if t.RegionCode != "" {
+		return t.LocaleCode + "-" + t.RegionCode
+	}
+
+	return t.LocaleCode
+}
+
+// The res directory that holds the translation
+func (t Translation) ResourceDirectory() string {
+	return filepath.Dir(filepath.Dir(t.Path))
+}
+
+// Check if the translation matches a locale given as a qualifier (pt-rBR) or
+// as a language (pt), which matches every region of the language
+func (t Translation) MatchesLocale(locale string) bool {
+	return locale == t.Qualifier() || (!t.IsDefault() && locale == t.LocaleCode)
+}
+
 func ContainsGoogleApiKey() bool {
 	return GOOGLE_API_KEY != ""
 }
 
-func TranslateText(text string, targetLanguage string, googleApiKey *string) (string, error) {
+// Translator backed by Google Cloud Translate v2 using an API key
+type GoogleTranslator struct {
+	apiKey   string
+	endpoint string
+}
+
+func NewGoogleTranslator(googleApiKey string) (*GoogleTranslator, error) {
 	key := GOOGLE_API_KEY
-	if googleApiKey != nil && *googleApiKey != "" {
-		key = *googleApiKey
+	if googleApiKey != "" {
+		key = googleApiKey
+	}
+
+	if key == "" {
+		return nil, fmt.Errorf("you need to pass the key through --googleApiKey flag or set the GOOGLE_TRANSLATE_KEY environment variable to use Google Translate")
+	}
+
+	return &GoogleTranslator{apiKey: key}, nil
+}
+
+func (g *GoogleTranslator) Name() string {
+	return ProviderGoogle
+}
+
+func (g *GoogleTranslator) newClient(ctx context.Context) (*translate.Client, error) {
+	options := []option.ClientOption{option.WithAPIKey(g.apiKey)}
+	if g.endpoint != "" {
+		options = append(options, option.WithEndpoint(g.endpoint))
+	}
+
+	client, err := translate.NewClient(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %v", err)
 	}
 
-	ctx := context.Background()
+	return client, nil
+}
 
-	client, err := translate.NewClient(ctx, option.WithAPIKey(key))
+func (g *GoogleTranslator) Translate(ctx context.Context, texts []string, source, target Translation) ([]string, error) {
+	client, err := g.newClient(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to create client: %v", err)
+		return nil, err
 	}
 	defer client.Close()
 
-	lang, err := language.Parse(targetLanguage)
+	targetLanguage, err := language.Parse(target.LocaleCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse target language: %v", err)
+	}
+
+	sourceLanguage, err := language.Parse(source.LocaleCode)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse target language: %v", err)
+		return nil, fmt.Errorf("failed to parse source language: %v", err)
 	}
 
 	resp, err := client.Translate(
 		ctx,
-		[]string{text},
-		lang,
-		&translate.Options{Format: translate.Text},
+		texts,
+		targetLanguage,
+		&translate.Options{Source: sourceLanguage, Format: translate.Text},
 	)
 	if err != nil {
-		return "", fmt.Errorf("failed to translate text: %v", err)
+		return nil, fmt.Errorf("failed to translate text: %v", err)
 	}
 
 	if len(resp) == 0 {
-		return "", fmt.Errorf("translation response is empty")
+		return nil, fmt.Errorf("translation response is empty")
+	}
+
+	translated := []string{}
+	for _, t := range resp {
+		translated = append(translated, t.Text)
+	}
+
+	return translated, nil
+}
+
+func (g *GoogleTranslator) SupportedLanguages(ctx context.Context) ([]string, error) {
+	client, err := g.newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	languages, err := client.SupportedLanguages(ctx, language.English)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list supported languages: %v", err)
+	}
+
+	codes := []string{}
+	for _, l := range languages {
+		codes = append(codes, l.Tag.String())
 	}
 
-	return resp[0].Text, nil
+	return codes, nil
 }
@@ -1,7 +1,12 @@
 package internal
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
 	"testing"
 )
 
@@ -42,3 +47,113 @@ func TestContainsGoogleApiKey(t *testing.T) {
 		}
 	})
 }
+
+func TestNewGoogleTranslatorWithoutKey(t *testing.T) {
+	os.Unsetenv("GOOGLE_TRANSLATE_KEY")
+	resetGoogleAPIKey()
+
+	_, err := NewGoogleTranslator("")
+	if err == nil {
+		t.Errorf("Expected an error, but got none")
+	}
+}
+
+func TestGoogleTranslatorTranslate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Failed to parse request: %v", err)
+		}
+
+		if r.Form.Get("key") != "KEY" || r.Form.Get("target") != "pt" || r.Form.Get("source") != "en" {
+			t.Errorf("Unexpected request parameters: %v", r.Form)
+		}
+
+		translations := []map[string]string{}
+		for _, q := range r.Form["q"] {
+			translations = append(translations, map[string]string{"translatedText": "pt:" + q})
+		}
+
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"translations": translations}})
+	}))
+	defer server.Close()
+
+	translator := &GoogleTranslator{apiKey: "KEY", endpoint: server.URL + "/language/translate/"}
+
+	got, err := translator.Translate(context.Background(), []string{"Hello", "World"}, DefaultSourceTranslation, Translation{LocaleCode: "pt", RegionCode: "BR"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, []string{"pt:Hello", "pt:World"}) {
+		t.Errorf("Translate() = %v, want %v", got, []string{"pt:Hello", "pt:World"})
+	}
+}
+
+func TestTranslationQualifier(t *testing.T) {
+	tests := []struct {
+		name             string
+		translation      Translation
+		isDefault        bool
+		qualifier        string
+		languageTag      string
+		resDirectory     string
+		matchingLocales  []string
+		differentLocales []string
+	}{
+		{
+			name:             "Default values",
+			translation:      Translation{Path: "app/src/main/res/values/strings.xml", LocaleCode: "en"},
+			isDefault:        true,
+			qualifier:        "",
+			languageTag:      "en",
+			resDirectory:     "app/src/main/res",
+			matchingLocales:  []string{""},
+			differentLocales: []string{"en"},
+		},
+		{
+			name:             "Language only",
+			translation:      Translation{Path: "app/src/main/res/values-pt/strings.xml", LocaleCode: "pt"},
+			qualifier:        "pt",
+			languageTag:      "pt",
+			resDirectory:     "app/src/main/res",
+			matchingLocales:  []string{"pt"},
+			differentLocales: []string{"pt-rBR", "es"},
+		},
+		{
+			name:             "Language with region",
+			translation:      Translation{Path: "app/src/main/res/values-pt-rBR/strings.xml", LocaleCode: "pt", RegionCode: "BR"},
+			qualifier:        "pt-rBR",
+			languageTag:      "pt-BR",
+			resDirectory:     "app/src/main/res",
+			matchingLocales:  []string{"pt", "pt-rBR"},
+			differentLocales: []string{"pt-rPT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.translation.IsDefault(); got != tt.isDefault {
+				t.Errorf("IsDefault() = %v, want %v", got, tt.isDefault)
+			}
+			if got := tt.translation.Qualifier(); got != tt.qualifier {
+				t.Errorf("Qualifier() = %v, want %v", got, tt.qualifier)
+			}
+			if got := tt.translation.LanguageTag(); got != tt.languageTag {
+				t.Errorf("LanguageTag() = %v, want %v", got, tt.languageTag)
+			}
+			if got := tt.translation.ResourceDirectory(); got != tt.resDirectory {
+				t.Errorf("ResourceDirectory() = %v, want %v", got, tt.resDirectory)
+			}
+			for _, locale := range tt.matchingLocales {
+				if !tt.translation.MatchesLocale(locale) {
+					t.Errorf("MatchesLocale(%q) = false, want true", locale)
+				}
+			}
+			for _, locale := range tt.differentLocales {
+				if tt.translation.MatchesLocale(locale) {
+					t.Errorf("MatchesLocale(%q) = true, want false", locale)
+				}
+			}
+		})
+	}
+}
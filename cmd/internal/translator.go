@@ -0,0 +1,214 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const ProviderGoogle = "google"
+
+// Environment variable used to select the translation provider when --provider is not set
+var TRANSLATION_PROVIDER = os.Getenv("POLYGLOT_TRANSLATION_PROVIDER")
+
+// Language of the strings in the default values/strings.xml
+var DefaultSourceTranslation = Translation{Language: "English", LocaleCode: "en"}
+
+// Translator is a machine translation engine used to translate the strings
+// of the resource files
+type Translator interface {
+	// Name of the provider as accepted by the --provider flag
+	Name() string
+	// Translate texts from source to target returning the translations in the same order
+	Translate(ctx context.Context, texts []string, source, target Translation) ([]string, error)
+	// Language codes accepted as translation target
+	SupportedLanguages(ctx context.Context) ([]string, error)
+}
+
+// Credentials and settings used to build a Translator
+type TranslatorOptions struct {
+	GoogleApiKey   string
+	DeepLAuthKey   string
+	DeepLFormality string
+
+	LibreTranslateURL    string
+	LibreTranslateApiKey string
+
+	// Provider of the project configuration, used when neither the flag nor
+	// the environment select one
+	DefaultProvider string
+}
+
+func AvailableProviders() []string {
+	return []string{ProviderGoogle, ProviderDeepL, ProviderLibreTranslate}
+}
+
+// Provider from the flag or, if not set, from the environment, then from the
+// project configuration with Google as fallback
+func ResolveProvider(provider, defaultProvider string) string {
+	if provider != "" {
+		return provider
+	}
+
+	if TRANSLATION_PROVIDER != "" {
+		return TRANSLATION_PROVIDER
+	}
+
+	if defaultProvider != "" {
+		return defaultProvider
+	}
+
+	return ProviderGoogle
+}
+
+func NewTranslator(provider string, options TranslatorOptions) (Translator, error) {
+	provider = ResolveProvider(provider, options.DefaultProvider)
+
+	switch strings.ToLower(provider) {
+	case ProviderGoogle:
+		return NewGoogleTranslator(options.GoogleApiKey)
+	case ProviderDeepL:
+		return NewDeepLTranslator(options.DeepLAuthKey, options.DeepLFormality)
+	case ProviderLibreTranslate:
+		return NewLibreTranslateTranslator(options.LibreTranslateURL, options.LibreTranslateApiKey)
+	}
+
+	return nil, fmt.Errorf("unknown translation provider %q, available providers: %v", provider, strings.Join(AvailableProviders(), ", "))
+}
+
+func TranslateText(translator Translator, text string, source, target Translation) (string, error) {
+	translated, err := TranslateTexts(translator, []string{text}, source, target)
+	if err != nil {
+		return "", err
+	}
+
+	return translated[0], nil
+}
+
+// Most texts each provider accepts in a single request
+var providerBatchSizes = map[string]int{ProviderGoogle: 128, ProviderDeepL: 50}
+
+// Texts sent in a single request to providers without a documented limit
+const defaultBatchSize = 50
+
+func batchSize(translator Translator) int {
+	if size, ok := providerBatchSizes[translator.Name()]; ok {
+		return size
+	}
+
+	return defaultBatchSize
+}
+
+// Translate a batch of texts with as few calls to the provider as its limit
+// of texts per request allows. Format arguments, markup and escapes are masked
+// before sending the texts and restored afterwards, so the provider cannot
+// break them.
+func TranslateTexts(translator Translator, texts []string, source, target Translation) ([]string, error) {
+	translated, errs, err := TranslateEachText(translator, texts, source, target)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return translated, nil
+}
+
+// Translate texts like TranslateTexts, but a text whose placeholders cannot be
+// restored does not fail the others. Its error is at the same index of errs
+// and its translation is empty.
+func TranslateEachText(translator Translator, texts []string, source, target Translation) (translated []string, errs []error, err error) {
+	if len(texts) == 0 {
+		return []string{}, []error{}, nil
+	}
+
+	masked := []string{}
+	placeholders := [][]string{}
+	for _, text := range texts {
+		m, p := MaskPlaceholders(text)
+		masked = append(masked, m)
+		placeholders = append(placeholders, p)
+	}
+
+	translated = []string{}
+	size := batchSize(translator)
+	for start := 0; start < len(masked); start += size {
+		batch := masked[start:min(start+size, len(masked))]
+
+		result, err := translator.Translate(context.Background(), batch, source, target)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to translate text with %v: %v", translator.Name(), err)
+		}
+
+		if len(result) != len(batch) {
+			return nil, nil, fmt.Errorf("%v returned %v translations for %v texts", translator.Name(), len(result), len(batch))
+		}
+
+		translated = append(translated, result...)
+	}
+
+	errs = make([]error, len(translated))
+	for i := range translated {
+		restored, err := RestorePlaceholders(translated[i], placeholders[i])
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to translate %q to %v with %v: %v", texts[i], target.Language, translator.Name(), err)
+		}
+		translated[i] = restored
+	}
+
+	return translated, errs, nil
+}
+
+// Send body encoded as JSON, if not nil, and decode the JSON response of an HTTP translation API
+func doJSONRequest(ctx context.Context, client *http.Client, method, url string, headers map[string]string, body any, response any) error {
+	var payload io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(content)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, payload)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to reach %v: %v", request.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%v responded with status %v: %v", request.URL.Host, resp.StatusCode, strings.TrimSpace(string(content)))
+	}
+
+	if err := json.Unmarshal(content, response); err != nil {
+		return fmt.Errorf("failed to decode response of %v: %v", request.URL.Host, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type fakeTranslator struct {
+	translate func(texts []string, target Translation) ([]string, error)
+}
+
+func (f fakeTranslator) Name() string {
+	return "fake"
+}
+
+func (f fakeTranslator) Translate(ctx context.Context, texts []string, source, target Translation) ([]string, error) {
+	return f.translate(texts, target)
+}
+
+func (f fakeTranslator) SupportedLanguages(ctx context.Context) ([]string, error) {
+	return []string{"pt"}, nil
+}
+
+// Translator that prefixes every text with the target locale
+func prefixTranslator() fakeTranslator {
+	return fakeTranslator{translate: func(texts []string, target Translation) ([]string, error) {
+		translated := []string{}
+		for _, text := range texts {
+			translated = append(translated, target.LocaleCode+":"+text)
+		}
+		return translated, nil
+	}}
+}
+
+func TestResolveProvider(t *testing.T) {
+	tests := []struct {
+		name       string
+		flag       string
+		envVal     string
+		configured string
+		expected   string
+	}{
+		{name: "Default", flag: "", envVal: "", expected: ProviderGoogle},
+		{name: "From environment", flag: "", envVal: "deepl", expected: "deepl"},
+		{name: "Flag overrides environment", flag: "google", envVal: "deepl", expected: "google"},
+		{name: "From configuration", flag: "", envVal: "", configured: "libretranslate", expected: "libretranslate"},
+		{name: "Environment overrides configuration", flag: "", envVal: "deepl", configured: "libretranslate", expected: "deepl"},
+		{name: "Flag overrides configuration", flag: "google", envVal: "", configured: "libretranslate", expected: "google"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			TRANSLATION_PROVIDER = tt.envVal
+			defer func() { TRANSLATION_PROVIDER = "" }()
+
+			got := ResolveProvider(tt.flag, tt.configured)
+			if got != tt.expected {
+				t.Errorf("ResolveProvider() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewTranslator(t *testing.T) {
+	t.Run("Unknown provider", func(t *testing.T) {
+		_, err := NewTranslator("palmeiras", TranslatorOptions{})
+		if err == nil || !strings.Contains(err.Error(), "unknown translation provider") {
+			t.Errorf("NewTranslator() error = %v, want unknown translation provider", err)
+		}
+	})
+
+	t.Run("Google with key", func(t *testing.T) {
+		translator, err := NewTranslator("Google", TranslatorOptions{GoogleApiKey: "KEY"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if translator.Name() != ProviderGoogle {
+			t.Errorf("Name() = %v, want %v", translator.Name(), ProviderGoogle)
+		}
+	})
+}
+
+func TestTranslateTexts(t *testing.T) {
+	target := Translation{LocaleCode: "pt"}
+
+	t.Run("Batch keeps order", func(t *testing.T) {
+		got, err := TranslateTexts(prefixTranslator(), []string{"a", "b"}, DefaultSourceTranslation, target)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(got, []string{"pt:a", "pt:b"}) {
+			t.Errorf("TranslateTexts() = %v", got)
+		}
+	})
+
+	t.Run("Empty batch does not call the provider", func(t *testing.T) {
+		translator := fakeTranslator{translate: func(texts []string, target Translation) ([]string, error) {
+			return nil, fmt.Errorf("should not be called")
+		}}
+
+		got, err := TranslateTexts(translator, []string{}, DefaultSourceTranslation, target)
+		if err != nil || len(got) != 0 {
+			t.Errorf("TranslateTexts() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("Missing translations in the response", func(t *testing.T) {
+		translator := fakeTranslator{translate: func(texts []string, target Translation) ([]string, error) {
+			return []string{"only one"}, nil
+		}}
+
+		_, err := TranslateTexts(translator, []string{"a", "b"}, DefaultSourceTranslation, target)
+		if err == nil {
+			t.Errorf("Expected an error, but got none")
+		}
+	})
+
+	t.Run("Batches within the limit of the provider", func(t *testing.T) {
+		batches := []int{}
+		translator := fakeTranslator{translate: func(texts []string, target Translation) ([]string, error) {
+			batches = append(batches, len(texts))
+			return prefixTranslator().translate(texts, target)
+		}}
+
+		texts := []string{}
+		for i := range 120 {
+			texts = append(texts, fmt.Sprint(i))
+		}
+
+		got, err := TranslateTexts(translator, texts, DefaultSourceTranslation, target)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(batches, []int{50, 50, 20}) {
+			t.Errorf("TranslateTexts() batches = %v, want [50 50 20]", batches)
+		}
+		if len(got) != 120 || got[0] != "pt:0" || got[119] != "pt:119" {
+			t.Errorf("TranslateTexts() = %v", got)
+		}
+	})
+
+	t.Run("Single text", func(t *testing.T) {
+		got, err := TranslateText(prefixTranslator(), "a", DefaultSourceTranslation, target)
+		if err != nil || got != "pt:a" {
+			t.Errorf("TranslateText() = %v, %v", got, err)
+		}
+	})
+}
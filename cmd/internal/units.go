@@ -0,0 +1,338 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// A text of a resource handed to translators: a string, an item of a
+// string-array or a quantity of a plurals. Units are identified by the key of
+// the resource followed by the index of the item or the quantity, e.g.
+// app_name, planets:0 and songs:one, which are valid XML name tokens.
+type TextUnit struct {
+	ID       string
+	Kind     string
+	Key      string
+	Selector string
+	// Values as written in strings.xml, Target is empty when the locale
+	// does not have the text yet
+	Source string
+	Target string
+	// Comment right above the resource in the default locale
+	Note string
+	// Review state of the target in the file it was imported from, e.g.
+	// translated or final in XLIFF
+	State string
+}
+
+func (u TextUnit) HasTarget() bool {
+	return u.Target != ""
+}
+
+var unitIDRegex = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_.]*)(?::([a-z]+|[0-9]+))?$`)
+
+func unitID(key, selector string) string {
+	if selector == "" {
+		return key
+	}
+
+	return key + ":" + selector
+}
+
+// Kind, key and selector of a unit ID, the kind is derived from the selector
+func ParseUnitID(id string) (TextUnit, error) {
+	matches := unitIDRegex.FindStringSubmatch(id)
+	if matches == nil {
+		return TextUnit{}, fmt.Errorf("invalid unit id %q", id)
+	}
+
+	u := TextUnit{ID: id, Kind: KindString, Key: matches[1], Selector: matches[2]}
+	switch {
+	case u.Selector == "":
+	case slices.Contains(PluralQuantities, u.Selector):
+		u.Kind = KindPlurals
+	default:
+		if _, err := strconv.Atoi(u.Selector); err != nil {
+			return TextUnit{}, fmt.Errorf("invalid unit id %q, unknown quantity %v", id, u.Selector)
+		}
+		u.Kind = KindStringArray
+	}
+
+	return u, nil
+}
+
+// Units of the translatable resources of source, the default locale, with
+// the values of target when it has them. Notes are the comments of source by
+// report key.
+func TextUnits(source, target Resources, notes map[string]string) []TextUnit {
+	units := []TextUnit{}
+
+	for _, s := range source.Strings {
+		if s.Translatable == "false" {
+			continue
+		}
+
+		u := TextUnit{ID: s.Key, Kind: KindString, Key: s.Key, Source: s.Value, Note: notes[s.Key]}
+		for _, t := range target.Strings {
+			if t.Key == s.Key {
+				u.Target = t.Value
+			}
+		}
+		units = append(units, u)
+	}
+
+	for _, p := range source.Plurals {
+		if p.Translatable == "false" {
+			continue
+		}
+
+		targetItems := map[string]string{}
+		for _, t := range target.Plurals {
+			if t.Key == p.Key {
+				for _, item := range t.Items {
+					targetItems[item.Quantity] = item.Value
+				}
+			}
+		}
+
+		for _, item := range p.Items {
+			units = append(units, TextUnit{
+				ID:       unitID(p.Key, item.Quantity),
+				Kind:     KindPlurals,
+				Key:      p.Key,
+				Selector: item.Quantity,
+				Source:   item.Value,
+				Target:   targetItems[item.Quantity],
+				Note:     notes[PluralsReportKey(p.Key)],
+			})
+		}
+	}
+
+	for _, a := range source.StringArrays {
+		if a.Translatable == "false" {
+			continue
+		}
+
+		targetItems := []string{}
+		for _, t := range target.StringArrays {
+			if t.Key == a.Key {
+				targetItems = t.Values()
+			}
+		}
+
+		for i, item := range a.Items {
+			u := TextUnit{
+				ID:       unitID(a.Key, strconv.Itoa(i)),
+				Kind:     KindStringArray,
+				Key:      a.Key,
+				Selector: strconv.Itoa(i),
+				Source:   item.Value,
+				Note:     notes[StringArrayReportKey(a.Key)],
+			}
+			if i < len(targetItems) {
+				u.Target = targetItems[i]
+			}
+			units = append(units, u)
+		}
+	}
+
+	return units
+}
+
+var entityRegex = regexp.MustCompile(`^&(?:[A-Za-z]+|#[0-9]+|#x[0-9A-Fa-f]+);`)
+
+// Text of a resource value shown to translators. The escaped apostrophes and
+// quotes and the &amp; entity are decoded, markup such as <b> is kept.
+func ResourceText(value string) string {
+	text := strings.Builder{}
+
+	inTag := false
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case inTag:
+			inTag = c != '>'
+		case c == '<':
+			inTag = true
+		case c == '\\' && i+1 < len(value) && (value[i+1] == '\'' || value[i+1] == '"'):
+			i++
+			c = value[i]
+		case strings.HasPrefix(value[i:], "&amp;"):
+			i += len("&amp;") - 1
+		}
+
+		text.WriteByte(c)
+	}
+
+	return text.String()
+}
+
+// Resource value of a text edited by translators, the inverse of ResourceText.
+// Apostrophes, quotes and ampersands outside of markup are escaped as Android
+// requires.
+func ResourceValue(text string) string {
+	return resourceValue(text, true)
+}
+
+// Resource value of text, with its markup kept when keepMarkup is set and
+// escaped as text otherwise
+func resourceValue(text string, keepMarkup bool) string {
+	value := strings.Builder{}
+
+	inTag := false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case inTag:
+			inTag = c != '>'
+		case keepMarkup && c == '<' && i+1 < len(text) && (isASCIILetter(text[i+1]) || text[i+1] == '/' || text[i+1] == '!'):
+			inTag = true
+		case c == '\\' && i+1 < len(text):
+			// Escapes such as \n and \' are kept as they are
+			value.WriteByte(c)
+			i++
+			c = text[i]
+		case c == '\'' || c == '"':
+			value.WriteByte('\\')
+		case c == '&' && !entityRegex.MatchString(text[i:]):
+			value.WriteString("&amp;")
+			continue
+		case c == '<':
+			value.WriteString("&lt;")
+			continue
+		}
+
+		value.WriteByte(c)
+	}
+
+	return value.String()
+}
+
+func isASCIILetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// Resources of r with the targets of units, which must be units of source, the
+// default locale. Strings are created or substituted, plurals get the
+// quantities of the units and string-arrays the items. A string-array that
+// would still miss items is left as it is and its key is returned. When
+// keepSorted is set and the resources of a kind are sorted by key, new
+// resources are inserted keeping them sorted.
+func (r Resources) WithTextUnits(source Resources, units []TextUnit, keepSorted bool) (Resources, []string) {
+	r.Strings = slices.Clone(r.Strings)
+	r.Plurals = slices.Clone(r.Plurals)
+	r.StringArrays = slices.Clone(r.StringArrays)
+
+	added := Resources{}
+	arrays := map[string][]string{}
+	arrayKeys := []string{}
+
+	for _, u := range units {
+		switch u.Kind {
+		case KindString:
+			index := slices.IndexFunc(r.Strings, func(s String) bool { return s.Key == u.Key })
+			if index >= 0 {
+				r.Strings[index].Value = u.Target
+				continue
+			}
+
+			index = slices.IndexFunc(added.Strings, func(s String) bool { return s.Key == u.Key })
+			if index >= 0 {
+				added.Strings[index].Value = u.Target
+				continue
+			}
+
+			added.Strings = append(added.Strings, String{XMLName: xml.Name{Local: "string"}, Key: u.Key, Value: u.Target})
+		case KindPlurals:
+			index := slices.IndexFunc(r.Plurals, func(p Plurals) bool { return p.Key == u.Key })
+			if index >= 0 {
+				r.Plurals[index].Items = withPluralItem(r.Plurals[index].Items, u.Selector, u.Target)
+				continue
+			}
+
+			index = slices.IndexFunc(added.Plurals, func(p Plurals) bool { return p.Key == u.Key })
+			if index >= 0 {
+				added.Plurals[index].Items = withPluralItem(added.Plurals[index].Items, u.Selector, u.Target)
+				continue
+			}
+
+			added.Plurals = append(added.Plurals, Plurals{
+				XMLName: xml.Name{Local: "plurals"},
+				Key:     u.Key,
+				Items:   withPluralItem(nil, u.Selector, u.Target),
+			})
+		case KindStringArray:
+			if _, ok := arrays[u.Key]; !ok {
+				arrays[u.Key] = arrayItems(source, r, u.Key)
+				arrayKeys = append(arrayKeys, u.Key)
+			}
+
+			index, _ := strconv.Atoi(u.Selector)
+			if index < len(arrays[u.Key]) {
+				arrays[u.Key][index] = u.Target
+			}
+		}
+	}
+
+	incomplete := []string{}
+	for _, key := range arrayKeys {
+		if slices.Contains(arrays[key], "") {
+			incomplete = append(incomplete, key)
+			continue
+		}
+
+		index := slices.IndexFunc(r.StringArrays, func(a StringArray) bool { return a.Key == key })
+		if index >= 0 {
+			r.StringArrays[index].Items = NewStringArray(key, arrays[key]).Items
+			continue
+		}
+
+		added.StringArrays = append(added.StringArrays, NewStringArray(key, arrays[key]))
+	}
+
+	return r.AddResources(added, keepSorted), incomplete
+}
+
+// Items of the plurals with the quantity set to value, in the order of the
+// quantities
+func withPluralItem(items []PluralItem, quantity, value string) []PluralItem {
+	items = slices.Clone(items)
+
+	index := slices.IndexFunc(items, func(item PluralItem) bool { return item.Quantity == quantity })
+	if index >= 0 {
+		items[index].Value = value
+		return items
+	}
+
+	order := slices.Index(PluralQuantities, quantity)
+	index = slices.IndexFunc(items, func(item PluralItem) bool { return slices.Index(PluralQuantities, item.Quantity) > order })
+	if index < 0 {
+		index = len(items)
+	}
+
+	return slices.Insert(items, index, PluralItem{XMLName: xml.Name{Local: "item"}, Quantity: quantity, Value: value})
+}
+
+// Current items of the string-array with key in r, with the length of the
+// string-array in source and the missing items empty
+func arrayItems(source, r Resources, key string) []string {
+	length := 0
+	for _, a := range source.StringArrays {
+		if a.Key == key {
+			length = len(a.Items)
+		}
+	}
+
+	items := make([]string, length)
+	for _, a := range r.StringArrays {
+		if a.Key == key {
+			copy(items, a.Values())
+		}
+	}
+
+	return items
+}
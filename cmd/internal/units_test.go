@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestTextUnits(t *testing.T) {
+	source := Resources{
+		Strings: []String{
+			{Key: "title", Value: "Title"},
+			{Key: "id", Value: "X", Translatable: "false"},
+		},
+		Plurals:      []Plurals{{Key: "songs", Items: []PluralItem{{Quantity: "one", Value: "%d song"}, {Quantity: "other", Value: "%d songs"}}}},
+		StringArrays: []StringArray{{Key: "planets", Items: []StringArrayItem{{Value: "Mercury"}, {Value: "Venus"}}}},
+	}
+	target := Resources{
+		Strings:      []String{{Key: "title", Value: "Título"}},
+		Plurals:      []Plurals{{Key: "songs", Items: []PluralItem{{Quantity: "other", Value: "%d músicas"}}}},
+		StringArrays: []StringArray{{Key: "planets", Items: []StringArrayItem{{Value: "Mercúrio"}}}},
+	}
+	notes := map[string]string{"title": "Home title", StringArrayReportKey("planets"): "Solar system"}
+
+	expected := []TextUnit{
+		{ID: "title", Kind: KindString, Key: "title", Source: "Title", Target: "Título", Note: "Home title"},
+		{ID: "songs:one", Kind: KindPlurals, Key: "songs", Selector: "one", Source: "%d song"},
+		{ID: "songs:other", Kind: KindPlurals, Key: "songs", Selector: "other", Source: "%d songs", Target: "%d músicas"},
+		{ID: "planets:0", Kind: KindStringArray, Key: "planets", Selector: "0", Source: "Mercury", Target: "Mercúrio", Note: "Solar system"},
+		{ID: "planets:1", Kind: KindStringArray, Key: "planets", Selector: "1", Source: "Venus", Note: "Solar system"},
+	}
+
+	got := TextUnits(source, target, notes)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("TextUnits() = %v, want %v", got, expected)
+	}
+}
+
+func TestParseUnitID(t *testing.T) {
+	tests := []struct {
+		id       string
+		expected TextUnit
+		wantErr  bool
+	}{
+		{"title", TextUnit{ID: "title", Kind: KindString, Key: "title"}, false},
+		{"songs:few", TextUnit{ID: "songs:few", Kind: KindPlurals, Key: "songs", Selector: "few"}, false},
+		{"planets:2", TextUnit{ID: "planets:2", Kind: KindStringArray, Key: "planets", Selector: "2"}, false},
+		{"songs:some", TextUnit{}, true},
+		{"with space", TextUnit{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, err := ParseUnitID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseUnitID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ParseUnitID() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResourceTextAndValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		text  string
+	}{
+		{"Plain", "Palmeiras", "Palmeiras"},
+		{"Apostrophe", `Palmeiras\' stadium`, "Palmeiras' stadium"},
+		{"Quote", `The \"Verdão\"`, `The "Verdão"`},
+		{"Ampersand", "Salt &amp; pepper", "Salt & pepper"},
+		{"Markup", `<b>Bold\'s</b> <a href="https://palmeiras.com.br">site</a>`, `<b>Bold's</b> <a href="https://palmeiras.com.br">site</a>`},
+		{"Escapes", `Line\nbreak`, `Line\nbreak`},
+		{"Entity", "&#8230;", "&#8230;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResourceText(tt.value); got != tt.text {
+				t.Errorf("ResourceText() = %q, want %q", got, tt.text)
+			}
+			if got := ResourceValue(tt.text); got != tt.value {
+				t.Errorf("ResourceValue() = %q, want %q", got, tt.value)
+			}
+		})
+	}
+
+	if got := ResourceValue("1 < 2"); got != "1 &lt; 2" {
+		t.Errorf("ResourceValue() = %q, want %q", got, "1 &lt; 2")
+	}
+}
+
+func TestWithTextUnits(t *testing.T) {
+	source := Resources{
+		Strings: []String{{Key: "a"}, {Key: "b"}, {Key: "c"}},
+		Plurals: []Plurals{{Key: "songs", Items: []PluralItem{{Quantity: "one"}, {Quantity: "few"}, {Quantity: "other"}}}},
+		StringArrays: []StringArray{
+			{Key: "planets", Items: []StringArrayItem{{Value: "Mercury"}, {Value: "Venus"}}},
+			{Key: "moons", Items: []StringArrayItem{{Value: "Io"}, {Value: "Europa"}}},
+		},
+	}
+	r := Resources{
+		Strings:      []String{{Key: "a", Value: "A"}, {Key: "c", Value: "C"}},
+		Plurals:      []Plurals{{Key: "songs", Items: []PluralItem{{Quantity: "other", Value: "%d músicas"}}}},
+		StringArrays: []StringArray{{Key: "planets", Items: []StringArrayItem{{Value: "Mercúrio"}}}},
+	}
+	units := []TextUnit{
+		{Kind: KindString, Key: "b", Target: "B"},
+		{Kind: KindString, Key: "c", Target: "C2"},
+		{Kind: KindPlurals, Key: "songs", Selector: "one", Target: "%d música"},
+		{Kind: KindStringArray, Key: "planets", Selector: "1", Target: "Vênus"},
+		{Kind: KindStringArray, Key: "moons", Selector: "1", Target: "Europa"},
+	}
+
+	got, incomplete := r.WithTextUnits(source, units, true)
+
+	expected := Resources{
+		Strings: []String{{Key: "a", Value: "A"}, {XMLName: xml.Name{Local: "string"}, Key: "b", Value: "B"}, {Key: "c", Value: "C2"}},
+		Plurals: []Plurals{{Key: "songs", Items: []PluralItem{
+			{XMLName: xml.Name{Local: "item"}, Quantity: "one", Value: "%d música"},
+			{Quantity: "other", Value: "%d músicas"},
+		}}},
+		StringArrays: []StringArray{{Key: "planets", Items: []StringArrayItem{
+			{XMLName: xml.Name{Local: "item"}, Value: "Mercúrio"},
+			{XMLName: xml.Name{Local: "item"}, Value: "Vênus"},
+		}}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("WithTextUnits() = %+v, want %+v", got, expected)
+	}
+	if !reflect.DeepEqual(incomplete, []string{"moons"}) {
+		t.Errorf("WithTextUnits() incomplete = %v, want [moons]", incomplete)
+	}
+	if r.Strings[1].Value != "C" {
+		t.Errorf("WithTextUnits() changed the original resources")
+	}
+}
@@ -0,0 +1,247 @@
+package internal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Kinds of resource references, as in R.string.key, R.plurals.key and R.array.key
+const (
+	UsageString  = "string"
+	UsagePlurals = "plurals"
+	UsageArray   = "array"
+)
+
+// Kind of the references to resources with the given tag, e.g. array for string-array
+func UsageKind(resourceKind string) string {
+	if resourceKind == KindStringArray {
+		return UsageArray
+	}
+
+	return resourceKind
+}
+
+// Sources of references to resources, each one can be turned off
+const (
+	// R.string.key in Kotlin, including stringResource(R.string.key) and R imported with an alias
+	UsageSourceKotlin = "kotlin"
+	// R.string.key in Java
+	UsageSourceJava = "java"
+	// @string/key in layouts, AndroidManifest.xml, navigation graphs, menus, data binding expressions and other resources
+	UsageSourceXML = "xml"
+	// Res.string.key of Compose Multiplatform resources
+	UsageSourceComposeResources = "compose-resources"
+	// getIdentifier("key", "string", packageName) in Kotlin and Java
+	UsageSourceGetIdentifier = "get-identifier"
+)
+
+func UsageSources() []string {
+	return []string{UsageSourceKotlin, UsageSourceJava, UsageSourceXML, UsageSourceComposeResources, UsageSourceGetIdentifier}
+}
+
+// Directories that never hold source code referencing resources
+var ignoredSourceDirectories = []string{".git", ".gradle", ".idea", "build", "node_modules"}
+
+// A kind of reference to resources and the files it is searched in
+type usageScanner struct {
+	extensions []string
+	find       func(content []byte) []reference
+}
+
+// A reference found in a file, start and end are the offsets of its key
+type reference struct {
+	kind  string
+	key   string
+	start int
+	end   int
+}
+
+var usageScanners = map[string]usageScanner{
+	UsageSourceKotlin: {
+		extensions: []string{".kt", ".kts"},
+		find:       findKotlinReferences,
+	},
+	UsageSourceJava: {
+		extensions: []string{".java"},
+		find:       regexFinder(resourceReferenceRegex, 1, 2),
+	},
+	UsageSourceXML: {
+		extensions: []string{".xml"},
+		find:       regexFinder(xmlReferenceRegex, 1, 2),
+	},
+	UsageSourceComposeResources: {
+		extensions: []string{".kt", ".kts"},
+		find:       regexFinder(composeResourceReferenceRegex, 1, 2),
+	},
+	UsageSourceGetIdentifier: {
+		extensions: []string{".kt", ".kts", ".java"},
+		find:       regexFinder(getIdentifierRegex, 2, 1),
+	},
+}
+
+var (
+	resourceReferenceRegex        = regexp.MustCompile(`\bR\.(string|plurals|array)\.([A-Za-z_][A-Za-z0-9_]*)\b`)
+	xmlReferenceRegex             = regexp.MustCompile(`@(string|plurals|array)/([A-Za-z_][A-Za-z0-9_]*)\b`)
+	composeResourceReferenceRegex = regexp.MustCompile(`\bRes\.(string|plurals|array)\.([A-Za-z_][A-Za-z0-9_]*)\b`)
+	getIdentifierRegex            = regexp.MustCompile(`\bgetIdentifier\(\s*"([A-Za-z_][A-Za-z0-9_]*)"\s*,\s*"(string|plurals|array)"`)
+	kotlinRImportAliasRegex       = regexp.MustCompile(`(?m)^\s*import\s+[\w.]*\bR\s+as\s+(\w+)`)
+)
+
+// Location of a reference to a resource
+type Usage struct {
+	Path string
+	Line int
+}
+
+// References to resources found in the source code, by kind and key
+type UsageIndex map[string][]Usage
+
+func usageIndexKey(kind, key string) string {
+	return kind + "/" + key
+}
+
+// Walk the source tree under root once and index every reference to a
+// resource found by the given sources
+func BuildUsageIndex(root string, sources []string) (UsageIndex, error) {
+	index := UsageIndex{}
+
+	err := filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != root && slices.Contains(ignoredSourceDirectories, entry.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !slices.ContainsFunc(sources, func(source string) bool { return scansFile(source, path) }) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		index.add(path, content, findReferences(path, content, sources))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+func scansFile(source, path string) bool {
+	return slices.Contains(usageScanners[source].extensions, filepath.Ext(path))
+}
+
+// References of the given sources found in the content of the file in path,
+// in the order they appear
+func findReferences(path string, content []byte, sources []string) []reference {
+	references := []reference{}
+	for _, source := range sources {
+		if scansFile(source, path) {
+			references = append(references, usageScanners[source].find(content)...)
+		}
+	}
+
+	slices.SortStableFunc(references, func(a, b reference) int { return a.start - b.start })
+
+	return references
+}
+
+// Finder of the matches of regex, with the kind and key of the resource in
+// the given submatches
+func regexFinder(regex *regexp.Regexp, kindGroup, keyGroup int) func(content []byte) []reference {
+	return func(content []byte) []reference {
+		references := []reference{}
+		for _, match := range regex.FindAllSubmatchIndex(content, -1) {
+			references = append(references, reference{
+				kind:  string(content[match[2*kindGroup]:match[2*kindGroup+1]]),
+				key:   string(content[match[2*keyGroup]:match[2*keyGroup+1]]),
+				start: match[2*keyGroup],
+				end:   match[2*keyGroup+1],
+			})
+		}
+
+		return references
+	}
+}
+
+// R.string.key references, also through the aliases R is imported with, e.g.
+// import com.palmeiras.core.R as CoreR
+func findKotlinReferences(content []byte) []reference {
+	regex := resourceReferenceRegex
+
+	aliases := []string{"R"}
+	for _, match := range kotlinRImportAliasRegex.FindAllSubmatch(content, -1) {
+		aliases = append(aliases, regexp.QuoteMeta(string(match[1])))
+	}
+	if len(aliases) > 1 {
+		regex = regexp.MustCompile(`\b(?:` + strings.Join(aliases, "|") + `)\.(string|plurals|array)\.([A-Za-z_][A-Za-z0-9_]*)\b`)
+	}
+
+	return regexFinder(regex, 1, 2)(content)
+}
+
+// Index the references of a file, counting lines only up to each reference
+func (idx UsageIndex) add(path string, content []byte, references []reference) {
+	line := 1
+	lineOffset := 0
+
+	for _, r := range references {
+		line += bytes.Count(content[lineOffset:r.start], []byte("\n"))
+		lineOffset = r.start
+
+		indexKey := usageIndexKey(r.kind, r.key)
+		idx[indexKey] = append(idx[indexKey], Usage{Path: path, Line: line})
+	}
+}
+
+// References to the resource of the given kind and key
+func (idx UsageIndex) Usages(kind, key string) []Usage {
+	return idx[usageIndexKey(kind, key)]
+}
+
+// Check if the resource of the given kind and key is referenced anywhere
+func (idx UsageIndex) IsUsed(kind, key string) bool {
+	return len(idx.Usages(kind, key)) > 0
+}
+
+// Replace the references of the given sources to the resource of kind with
+// key from by references to key to. The content of the file in path is
+// returned with the number of references replaced.
+func RenameReferences(path string, content []byte, sources []string, kind, from, to string) ([]byte, int) {
+	var buffer bytes.Buffer
+	renamed := 0
+	offset := 0
+
+	for _, r := range findReferences(path, content, sources) {
+		if r.kind != kind || r.key != from || r.start < offset {
+			continue
+		}
+
+		buffer.Write(content[offset:r.start])
+		buffer.WriteString(to)
+		offset = r.end
+		renamed++
+	}
+
+	if renamed == 0 {
+		return content, 0
+	}
+
+	buffer.Write(content[offset:])
+
+	return buffer.Bytes(), renamed
+}
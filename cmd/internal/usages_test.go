@@ -0,0 +1,214 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBuildUsageIndex(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		"app/src/main/java/Main.kt": `package palmeiras
+
+fun title() = getString(R.string.app_name)
+
+fun titles(count: Int) = resources.getQuantityString(R.plurals.titles, count, count) + getString(R.string.app_name)
+val players = resources.getStringArray(R.array.players)
+`,
+		"app/src/main/java/Other.kt":             "val label = R.string.app_name_short\nval notAKey = R.stringy.value\n",
+		"app/build/generated/Generated.kt":       "val generated = R.string.generated\n",
+		"app/src/main/java/Ignored.txt":          "R.string.ignored\n",
+		"app/src/main/res/values/strings.xml.kt": "R.string.from_any_kotlin_file\n",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(root, path)
+		os.MkdirAll(filepath.Dir(fullPath), 0o755)
+		os.WriteFile(fullPath, []byte(content), 0o644)
+	}
+
+	index, err := BuildUsageIndex(root, []string{UsageSourceKotlin})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	main := filepath.Join(root, "app/src/main/java/Main.kt")
+
+	tests := []struct {
+		kind     string
+		key      string
+		expected []Usage
+	}{
+		{kind: UsageString, key: "app_name", expected: []Usage{{Path: main, Line: 3}, {Path: main, Line: 5}}},
+		{kind: UsagePlurals, key: "titles", expected: []Usage{{Path: main, Line: 5}}},
+		{kind: UsageArray, key: "players", expected: []Usage{{Path: main, Line: 6}}},
+		{kind: UsageString, key: "app_name_short", expected: []Usage{{Path: filepath.Join(root, "app/src/main/java/Other.kt"), Line: 1}}},
+		{kind: UsageString, key: "from_any_kotlin_file", expected: []Usage{{Path: filepath.Join(root, "app/src/main/res/values/strings.xml.kt"), Line: 1}}},
+		{kind: UsageString, key: "value", expected: nil},
+		{kind: UsageString, key: "generated", expected: nil},
+		{kind: UsageString, key: "ignored", expected: nil},
+		{kind: UsageString, key: "titles", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind+"/"+tt.key, func(t *testing.T) {
+			got := index.Usages(tt.kind, tt.key)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Usages() = %v, want %v", got, tt.expected)
+			}
+
+			if index.IsUsed(tt.kind, tt.key) != (len(tt.expected) > 0) {
+				t.Errorf("IsUsed() = %v, want %v", index.IsUsed(tt.kind, tt.key), len(tt.expected) > 0)
+			}
+		})
+	}
+}
+
+func TestBuildUsageIndexSources(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		"app/src/main/java/Main.java": `class Main {
+    String title = getString(R.string.java_title);
+    int id = getResources().getIdentifier("java_dynamic", "string", getPackageName());
+}
+`,
+		"app/src/main/java/Screen.kt": `package palmeiras
+
+import com.palmeiras.core.R as CoreR
+import palmeiras.generated.resources.Res
+
+@Composable
+fun Screen() {
+    Text(stringResource(CoreR.string.compose_title))
+    Text(stringResource(Res.string.multiplatform_title))
+    val id = resources.getIdentifier("kotlin_dynamic", "string", packageName)
+}
+`,
+		"app/src/main/res/layout/activity_main.xml": `<LinearLayout>
+    <TextView android:text="@string/layout_title" />
+    <TextView android:text="@{viewModel.isEmpty ? @string/binding_empty : @string/binding_full}" />
+    <Button android:text="@android:string/ok" />
+</LinearLayout>
+`,
+		"app/src/main/AndroidManifest.xml":          "<application android:label=\"@string/app_name\" />\n",
+		"app/src/main/res/navigation/nav_graph.xml": "<fragment android:label=\"@string/nav_home\" />\n",
+		"app/src/main/res/values/strings.xml":       "<resources>\n    <string name=\"alias\">@string/app_name</string>\n</resources>\n",
+		"app/src/main/res/values/arrays.xml":        "<string-array name=\"players\">\n    <item>@string/player_one</item>\n</string-array>\n",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(root, path)
+		os.MkdirAll(filepath.Dir(fullPath), 0o755)
+		os.WriteFile(fullPath, []byte(content), 0o644)
+	}
+
+	tests := []struct {
+		key      string
+		source   string
+		expected int
+	}{
+		{key: "java_title", source: UsageSourceJava, expected: 1},
+		{key: "java_dynamic", source: UsageSourceGetIdentifier, expected: 1},
+		{key: "kotlin_dynamic", source: UsageSourceGetIdentifier, expected: 1},
+		{key: "compose_title", source: UsageSourceKotlin, expected: 1},
+		{key: "multiplatform_title", source: UsageSourceComposeResources, expected: 1},
+		{key: "layout_title", source: UsageSourceXML, expected: 1},
+		{key: "binding_empty", source: UsageSourceXML, expected: 1},
+		{key: "binding_full", source: UsageSourceXML, expected: 1},
+		{key: "app_name", source: UsageSourceXML, expected: 2},
+		{key: "nav_home", source: UsageSourceXML, expected: 1},
+		{key: "player_one", source: UsageSourceXML, expected: 1},
+		{key: "ok", source: UsageSourceXML, expected: 0},
+	}
+
+	all, err := BuildUsageIndex(root, UsageSources())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := len(all.Usages(UsageString, tt.key)); got != tt.expected {
+				t.Errorf("len(Usages()) = %v, want %v", got, tt.expected)
+			}
+
+			others := []string{}
+			for _, source := range UsageSources() {
+				if source != tt.source {
+					others = append(others, source)
+				}
+			}
+
+			index, err := BuildUsageIndex(root, others)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if index.IsUsed(UsageString, tt.key) {
+				t.Errorf("IsUsed() = true with source %v turned off", tt.source)
+			}
+		})
+	}
+}
+
+func TestRenameReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		kind     string
+		expected string
+		renamed  int
+	}{
+		{
+			name:     "Kotlin with alias",
+			path:     "Main.kt",
+			content:  "import com.palmeiras.R as AppR\nval a = AppR.string.title + R.string.title_short + R.plurals.title\n",
+			kind:     UsageString,
+			expected: "import com.palmeiras.R as AppR\nval a = AppR.string.header + R.string.title_short + R.plurals.title\n",
+			renamed:  1,
+		},
+		{
+			name:     "Plurals",
+			path:     "Main.kt",
+			content:  "val a = R.string.title + R.plurals.title\n",
+			kind:     UsagePlurals,
+			expected: "val a = R.string.title + R.plurals.header\n",
+			renamed:  1,
+		},
+		{
+			name:     "XML",
+			path:     "main.xml",
+			content:  "<TextView android:text=\"@string/title\" android:hint=\"@android:string/title\" />\n",
+			kind:     UsageString,
+			expected: "<TextView android:text=\"@string/header\" android:hint=\"@android:string/title\" />\n",
+			renamed:  1,
+		},
+		{
+			name:     "Java and getIdentifier",
+			path:     "Main.java",
+			content:  "int a = R.string.title;\nint b = getIdentifier(\"title\", \"string\", packageName);\n",
+			kind:     UsageString,
+			expected: "int a = R.string.header;\nint b = getIdentifier(\"header\", \"string\", packageName);\n",
+			renamed:  2,
+		},
+		{
+			name:     "No references",
+			path:     "Main.kt",
+			content:  "val a = R.string.subtitle\n",
+			kind:     UsageString,
+			expected: "val a = R.string.subtitle\n",
+			renamed:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, renamed := RenameReferences(tt.path, []byte(tt.content), UsageSources(), tt.kind, "title", "header")
+			if string(got) != tt.expected || renamed != tt.renamed {
+				t.Errorf("RenameReferences() = %q, %v, want %q, %v", got, renamed, tt.expected, tt.renamed)
+			}
+		})
+	}
+}
@@ -1,26 +1,62 @@
 package internal
 
 import (
-	"bytes"
 	"fmt"
 	"os"
-	"os/exec"
 	"regexp"
-	"runtime"
 
+	"polyglot/cmd/ui/multiselect"
 	"polyglot/cmd/ui/singleselect"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
 )
 
-func IsWindows() bool {
-	return runtime.GOOS == "windows"
-}
-
-func GetTranslations(allModules bool) ([]Translation, error) {
+func GetTranslations(allModules bool, resDirectory string) ([]Translation, error) {
 	if allModules {
 		return GetTranslationsFromAllModules()
 	}
+	return SelectResDirectoryAndReturnTranslations(resDirectory)
+}
+
+// Check if stdin is a terminal where the interactive selection can be shown
+var IsInteractive = func() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// Return the translations of resDirectory when given. Otherwise select the only
+// resource directory of the project or ask the user to select one.
+func SelectResDirectoryAndReturnTranslations(resDirectory string) ([]Translation, error) {
+	if resDirectory != "" {
+		if !isAndroidResourceDirectory(resDirectory) {
+			return nil, fmt.Errorf("%v is not an android resource directory", resDirectory)
+		}
+
+		return GetTranslationsFromResourceDirectory(resDirectory)
+	}
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	resDirs, err := FindResourcesDirectoriesPath(currentDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(resDirs) == 0 {
+		return nil, fmt.Errorf("no android resource directories found")
+	}
+
+	if len(resDirs) == 1 {
+		fmt.Fprintf(os.Stderr, "Using the only resource directory found: %v\n", resDirs[0])
+		return GetTranslationsFromResourceDirectory(resDirs[0])
+	}
+
+	if !IsInteractive() {
+		return nil, fmt.Errorf("found %v resource directories and stdin is not a terminal, select one with --res or --module", len(resDirs))
+	}
+
 	return SingleSelectResDirectoryAndReturnTranslations()
 }
 
@@ -57,61 +93,21 @@ func SingleSelectResDirectoryAndReturnTranslations() ([]Translation, error) {
 	return translations, nil
 }
 
-func IsKeyBeingUsed(key string) (bool, error) {
-	if IsWindows() {
-		return false, fmt.Errorf("not supported on Windows")
-	}
-
-	_, err := exec.LookPath("rg")
-	if err == nil {
-		return IsKeyBeingUsedRipGrep(key)
-	}
-
-	return IsKeyBeingUsedGrep(key)
-}
-
-func IsKeyBeingUsedRipGrep(key string) (bool, error) {
-	k := fmt.Sprintf("R.string.%v", key)
-
-	cmd := exec.Command("rg", k, "--glob=*.kt")
-
-	var output bytes.Buffer
-	cmd.Stderr = &output
-
-	err := cmd.Run()
-	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			if exitErr.ExitCode() == 1 {
-				return false, nil
-			}
-		}
+// Ask the user which of the choices to keep, all of them are selected at first.
+// Nothing is returned when the user cancels.
+func MultiSelect(title string, choices []string) ([]string, error) {
+	selection := multiselect.InitialSelection(choices)
 
-		return false, err
+	tprogram := tea.NewProgram(multiselect.InitialModelMultiSelect(title, choices, &selection))
+	if _, err := tprogram.Run(); err != nil {
+		return nil, err
 	}
 
-	return true, nil
-}
-
-func IsKeyBeingUsedGrep(key string) (bool, error) {
-	k := fmt.Sprintf("R.string.%v", key)
-
-	cmd := exec.Command("grep", "-r", k, "--include=*.kt", ".")
-
-	var output bytes.Buffer
-	cmd.Stderr = &output
-
-	err := cmd.Run()
-	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			if exitErr.ExitCode() == 1 {
-				return false, nil
-			}
-		}
-
-		return false, err
+	if !selection.Confirmed {
+		return nil, nil
 	}
 
-	return true, nil
+	return selection.Choices(choices), nil
 }
 
 func IsStringKeyValid(k string) bool {
@@ -1,7 +1,10 @@
 package internal
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -109,3 +112,87 @@ func TestIsKeyValidPrintMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectResDirectoryAndReturnTranslations(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         []string
+		resDirectory  string
+		expectedPaths []string
+		errorContains string
+	}{
+		{
+			name:          "Explicit resource directory",
+			files:         []string{"app/src/main/res/values/strings.xml", "lib/src/main/res/values/strings.xml"},
+			resDirectory:  "lib/src/main/res",
+			expectedPaths: []string{"lib/src/main/res/values/strings.xml"},
+		},
+		{
+			name:          "Explicit directory that is not a resource directory",
+			files:         []string{"app/src/main/res/values/strings.xml"},
+			resDirectory:  "app/src/main",
+			errorContains: "not an android resource directory",
+		},
+		{
+			name:          "Only one resource directory is selected automatically",
+			files:         []string{"app/src/main/res/values/strings.xml", "app/src/main/res/values-pt/strings.xml"},
+			expectedPaths: []string{"app/src/main/res/values/strings.xml", "app/src/main/res/values-pt/strings.xml"},
+		},
+		{
+			name:          "Several resource directories without a terminal",
+			files:         []string{"app/src/main/res/values/strings.xml", "lib/src/main/res/values/strings.xml"},
+			errorContains: "stdin is not a terminal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for _, file := range tt.files {
+				path := filepath.Join(tmpDir, file)
+				os.MkdirAll(filepath.Dir(path), 0o755)
+				os.WriteFile(path, []byte("<resources></resources>"), 0o644)
+			}
+
+			oldDir, _ := os.Getwd()
+			defer os.Chdir(oldDir)
+			if err := os.Chdir(tmpDir); err != nil {
+				t.Fatalf("failed to change directory: %v", err)
+			}
+
+			isInteractive := IsInteractive
+			IsInteractive = func() bool { return false }
+			defer func() { IsInteractive = isInteractive }()
+
+			var translations []Translation
+			var err error
+			CaptureStdout(func() {
+				translations, err = SelectResDirectoryAndReturnTranslations(tt.resDirectory)
+			})
+
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Errorf("SelectResDirectoryAndReturnTranslations() error = %v, want %v", err, tt.errorContains)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			paths := []string{}
+			for _, translation := range translations {
+				path := translation.Path
+				if filepath.IsAbs(path) {
+					path, _ = filepath.Rel(tmpDir, path)
+				}
+				paths = append(paths, filepath.ToSlash(path))
+			}
+
+			if !reflect.DeepEqual(paths, tt.expectedPaths) {
+				t.Errorf("SelectResDirectoryAndReturnTranslations() = %v, want %v", paths, tt.expectedPaths)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package internal
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Versions of XLIFF, the exchange format of CAT tools used by translation
+// agencies
+const (
+	XliffVersion12 = "1.2"
+	XliffVersion20 = "2.0"
+)
+
+func XliffVersions() []string {
+	return []string{XliffVersion12, XliffVersion20}
+}
+
+const (
+	xliff12Namespace = "urn:oasis:names:tc:xliff:document:1.2"
+	xliff20Namespace = "urn:oasis:names:tc:xliff:document:2.0"
+)
+
+type xliff12 struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Version string        `xml:"version,attr"`
+	Files   []xliff12File `xml:"file"`
+}
+
+type xliff12File struct {
+	Original       string        `xml:"original,attr"`
+	SourceLanguage string        `xml:"source-language,attr"`
+	TargetLanguage string        `xml:"target-language,attr,omitempty"`
+	Datatype       string        `xml:"datatype,attr"`
+	Units          []xliff12Unit `xml:"body>trans-unit"`
+}
+
+type xliff12Unit struct {
+	ID      string         `xml:"id,attr"`
+	Resname string         `xml:"resname,attr,omitempty"`
+	Source  string         `xml:"source"`
+	Target  *xliff12Target `xml:"target"`
+	Notes   []string       `xml:"note"`
+}
+
+type xliff12Target struct {
+	State string `xml:"state,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+type xliff20 struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Version string        `xml:"version,attr"`
+	SrcLang string        `xml:"srcLang,attr"`
+	TrgLang string        `xml:"trgLang,attr,omitempty"`
+	Files   []xliff20File `xml:"file"`
+}
+
+type xliff20File struct {
+	ID       string        `xml:"id,attr"`
+	Original string        `xml:"original,attr,omitempty"`
+	Units    []xliff20Unit `xml:"unit"`
+}
+
+type xliff20Unit struct {
+	ID       string           `xml:"id,attr"`
+	Name     string           `xml:"name,attr,omitempty"`
+	Notes    *xliff20Notes    `xml:"notes"`
+	Segments []xliff20Segment `xml:"segment"`
+}
+
+// Notes of a unit, XLIFF 2.0 does not allow an empty notes element
+type xliff20Notes struct {
+	Notes []string `xml:"note"`
+}
+
+type xliff20Segment struct {
+	State  string  `xml:"state,attr,omitempty"`
+	Source string  `xml:"source"`
+	Target *string `xml:"target"`
+}
+
+// XLIFF document of the units of the strings.xml file in original, translated
+// from the source to the target locale. Units without a target are left for
+// the translators, the others are marked as translated.
+func RenderXliff(version, original string, source, target Translation, units []TextUnit) ([]byte, error) {
+	var document any
+
+	switch version {
+	case XliffVersion12:
+		file := xliff12File{
+			Original:       original,
+			SourceLanguage: source.LanguageTag(),
+			TargetLanguage: target.LanguageTag(),
+			Datatype:       "xml",
+			Units:          []xliff12Unit{},
+		}
+
+		for _, u := range units {
+			unit := xliff12Unit{ID: u.ID, Resname: u.Key, Source: ResourceText(u.Source)}
+			if u.HasTarget() {
+				unit.Target = &xliff12Target{State: "translated", Text: ResourceText(u.Target)}
+			}
+			if u.Note != "" {
+				unit.Notes = []string{u.Note}
+			}
+			file.Units = append(file.Units, unit)
+		}
+
+		document = xliff12{Xmlns: xliff12Namespace, Version: version, Files: []xliff12File{file}}
+	case XliffVersion20:
+		file := xliff20File{ID: "f1", Original: original, Units: []xliff20Unit{}}
+
+		for _, u := range units {
+			segment := xliff20Segment{State: "initial", Source: ResourceText(u.Source)}
+			if u.HasTarget() {
+				text := ResourceText(u.Target)
+				segment.State = "translated"
+				segment.Target = &text
+			}
+
+			unit := xliff20Unit{ID: u.ID, Name: u.Key, Segments: []xliff20Segment{segment}}
+			if u.Note != "" {
+				unit.Notes = &xliff20Notes{Notes: []string{u.Note}}
+			}
+			file.Units = append(file.Units, unit)
+		}
+
+		document = xliff20{
+			Xmlns:   xliff20Namespace,
+			Version: version,
+			SrcLang: source.LanguageTag(),
+			TrgLang: target.LanguageTag(),
+			Files:   []xliff20File{file},
+		}
+	default:
+		return nil, fmt.Errorf("unknown XLIFF version %q, available versions: %v, %v", version, XliffVersion12, XliffVersion20)
+	}
+
+	output, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header+string(output)), '\n'), nil
+}
+
+// Target language and units of an XLIFF document, the units have the target
+// text decoded and the state of the target
+type XliffDocument struct {
+	TargetLanguage string
+	Units          []TextUnit
+}
+
+// Parse an XLIFF 1.2 or 2.0 document. The kind, key and selector of the units
+// are only set when their ID is a unit ID, units created by other tools keep
+// just the ID.
+func ParseXliff(content []byte) (XliffDocument, error) {
+	var header struct {
+		Version string `xml:"version,attr"`
+	}
+	if err := xml.Unmarshal(content, &header); err != nil {
+		return XliffDocument{}, fmt.Errorf("error parsing XLIFF: %v", err)
+	}
+
+	document := XliffDocument{Units: []TextUnit{}}
+	setTargetLanguage := func(language string) error {
+		if document.TargetLanguage != "" && language != document.TargetLanguage {
+			return fmt.Errorf("the XLIFF files have different target languages, %v and %v", document.TargetLanguage, language)
+		}
+		document.TargetLanguage = language
+		return nil
+	}
+
+	switch header.Version {
+	case XliffVersion12:
+		var x xliff12
+		if err := xml.Unmarshal(content, &x); err != nil {
+			return XliffDocument{}, fmt.Errorf("error parsing XLIFF: %v", err)
+		}
+
+		for _, file := range x.Files {
+			if err := setTargetLanguage(file.TargetLanguage); err != nil {
+				return XliffDocument{}, err
+			}
+
+			for _, unit := range file.Units {
+				u := parsedUnit(unit.ID, unit.Source)
+				if unit.Target != nil {
+					u.Target = ResourceValue(unit.Target.Text)
+					u.State = unit.Target.State
+				}
+				document.Units = append(document.Units, u)
+			}
+		}
+	case XliffVersion20:
+		var x xliff20
+		if err := xml.Unmarshal(content, &x); err != nil {
+			return XliffDocument{}, fmt.Errorf("error parsing XLIFF: %v", err)
+		}
+
+		if err := setTargetLanguage(x.TrgLang); err != nil {
+			return XliffDocument{}, err
+		}
+
+		for _, file := range x.Files {
+			for _, unit := range file.Units {
+				// A unit split in segments is translated when all of them are,
+				// so it gets the least advanced state of its segments
+				source, target := strings.Builder{}, strings.Builder{}
+				state := ""
+				for _, segment := range unit.Segments {
+					source.WriteString(segment.Source)
+					if segment.Target != nil {
+						target.WriteString(*segment.Target)
+					}
+					if state == "" || xliff20StateOrder(segment.State) < xliff20StateOrder(state) {
+						state = cmp.Or(segment.State, "initial")
+					}
+				}
+
+				u := parsedUnit(unit.ID, source.String())
+				u.Target = ResourceValue(target.String())
+				u.State = state
+				document.Units = append(document.Units, u)
+			}
+		}
+	default:
+		return XliffDocument{}, fmt.Errorf("unknown XLIFF version %q, available versions: %v, %v", header.Version, XliffVersion12, XliffVersion20)
+	}
+
+	if document.TargetLanguage == "" {
+		return XliffDocument{}, fmt.Errorf("the XLIFF file has no target language")
+	}
+
+	return document, nil
+}
+
+func parsedUnit(id, source string) TextUnit {
+	u, err := ParseUnitID(id)
+	if err != nil {
+		u = TextUnit{ID: id}
+	}
+	u.Source = ResourceValue(source)
+
+	return u
+}
+
+// States of XLIFF 2.0 segments, from the least to the most advanced
+var xliff20States = []string{"initial", "translated", "reviewed", "final"}
+
+func xliff20StateOrder(state string) int {
+	return slices.Index(xliff20States, cmp.Or(state, "initial"))
+}
+
+// Check if a target with the given state is ready to be imported: translated
+// or final in both versions, and reviewed in XLIFF 2.0, which comes between
+// them. XLIFF 1.2 states such as new or needs-review-translation are not.
+func IsXliffStateTranslated(state string) bool {
+	return slices.Contains([]string{"translated", "reviewed", "final"}, state)
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenderXliff(t *testing.T) {
+	source := Translation{LocaleCode: "en"}
+	target := Translation{LocaleCode: "pt", RegionCode: "BR"}
+	units := []TextUnit{
+		{ID: "title", Key: "title", Source: `Palmeiras\' &amp; <b>Allianz</b>`, Target: "Título", Note: "Home title"},
+		{ID: "songs:one", Key: "songs", Source: "%d song"},
+	}
+
+	tests := []struct {
+		version  string
+		expected string
+		wantErr  bool
+	}{
+		{XliffVersion12, `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="res/values-pt-rBR/strings.xml" source-language="en" target-language="pt-BR" datatype="xml">
+    <body>
+      <trans-unit id="title" resname="title">
+        <source>Palmeiras&#39; &amp; &lt;b&gt;Allianz&lt;/b&gt;</source>
+        <target state="translated">Título</target>
+        <note>Home title</note>
+      </trans-unit>
+      <trans-unit id="songs:one" resname="songs">
+        <source>%d song</source>
+      </trans-unit>
+    </body>
+  </file>
+</xliff>
+`, false},
+		{XliffVersion20, `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en" trgLang="pt-BR">
+  <file id="f1" original="res/values-pt-rBR/strings.xml">
+    <unit id="title" name="title">
+      <notes>
+        <note>Home title</note>
+      </notes>
+      <segment state="translated">
+        <source>Palmeiras&#39; &amp; &lt;b&gt;Allianz&lt;/b&gt;</source>
+        <target>Título</target>
+      </segment>
+    </unit>
+    <unit id="songs:one" name="songs">
+      <segment state="initial">
+        <source>%d song</source>
+      </segment>
+    </unit>
+  </file>
+</xliff>
+`, false},
+		{"1.0", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := RenderXliff(tt.version, "res/values-pt-rBR/strings.xml", source, target, units)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderXliff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("RenderXliff() = %v, want %v", string(got), tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseXliff(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected XliffDocument
+		wantErr  bool
+	}{
+		{
+			name: "XLIFF 1.2",
+			content: `<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="strings.xml" source-language="en" target-language="pt-BR" datatype="xml">
+    <body>
+      <trans-unit id="title"><source>Palmeiras&#39; &lt;b&gt;club&lt;/b&gt;</source><target state="final">Clube &amp; &lt;b&gt;Palmeiras&lt;/b&gt;</target></trans-unit>
+      <trans-unit id="songs:one"><source>%d song</source><target state="needs-review-translation">%d música</target></trans-unit>
+      <trans-unit id="planets:0"><source>Mercury</source></trans-unit>
+      <trans-unit id="other tool"><source>Text</source></trans-unit>
+    </body>
+  </file>
+</xliff>`,
+			expected: XliffDocument{TargetLanguage: "pt-BR", Units: []TextUnit{
+				{ID: "title", Kind: KindString, Key: "title", Source: `Palmeiras\' <b>club</b>`, Target: "Clube &amp; <b>Palmeiras</b>", State: "final"},
+				{ID: "songs:one", Kind: KindPlurals, Key: "songs", Selector: "one", Source: "%d song", Target: "%d música", State: "needs-review-translation"},
+				{ID: "planets:0", Kind: KindStringArray, Key: "planets", Selector: "0", Source: "Mercury"},
+				{ID: "other tool", Source: "Text"},
+			}},
+		},
+		{
+			name: "XLIFF 2.0",
+			content: `<xliff xmlns="urn:oasis:names:tc:xliff:document:2.0" version="2.0" srcLang="en" trgLang="es">
+  <file id="f1">
+    <unit id="title"><segment state="reviewed"><source>Title</source><target>Título</target></segment></unit>
+    <unit id="body">
+      <segment state="final"><source>First. </source><target>Primero. </target></segment>
+      <segment><source>Second.</source></segment>
+    </unit>
+  </file>
+</xliff>`,
+			expected: XliffDocument{TargetLanguage: "es", Units: []TextUnit{
+				{ID: "title", Kind: KindString, Key: "title", Source: "Title", Target: "Título", State: "reviewed"},
+				{ID: "body", Kind: KindString, Key: "body", Source: "First. Second.", Target: "Primero. ", State: "initial"},
+			}},
+		},
+		{
+			name:    "Unknown version",
+			content: `<xliff version="1.1"></xliff>`,
+			wantErr: true,
+		},
+		{
+			name:    "Without target language",
+			content: `<xliff version="2.0" srcLang="en"></xliff>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseXliff([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseXliff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ParseXliff() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsXliffStateTranslated(t *testing.T) {
+	for state, expected := range map[string]bool{
+		"translated": true, "reviewed": true, "final": true,
+		"": false, "new": false, "initial": false, "needs-review-translation": false,
+	} {
+		if got := IsXliffStateTranslated(state); got != expected {
+			t.Errorf("IsXliffStateTranslated(%q) = %v, want %v", state, got, expected)
+		}
+	}
+}
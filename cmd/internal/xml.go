@@ -1,18 +1,27 @@
 package internal
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"regexp"
 	"slices"
 	"sort"
 	"strings"
+
+	"github.com/aymanbagabas/go-udiff"
+	"github.com/aymanbagabas/go-udiff/myers"
 )
 
 type Resources struct {
-	XMLName     xml.Name    `xml:"resources"`
-	Strings     []String    `xml:"string"`
-	Translation Translation `xml:"-"`
+	XMLName      xml.Name      `xml:"resources"`
+	Strings      []String      `xml:"string"`
+	Plurals      []Plurals     `xml:"plurals"`
+	StringArrays []StringArray `xml:"string-array"`
+	Translation  Translation   `xml:"-"`
 }
 
 type String struct {
@@ -22,6 +31,43 @@ type String struct {
 	Translatable string `xml:"translatable,attr,omitempty"`
 }
 
+// Quantities accepted by the quantity attribute of a plurals item
+const (
+	QuantityZero  = "zero"
+	QuantityOne   = "one"
+	QuantityTwo   = "two"
+	QuantityFew   = "few"
+	QuantityMany  = "many"
+	QuantityOther = "other"
+)
+
+var PluralQuantities = []string{QuantityZero, QuantityOne, QuantityTwo, QuantityFew, QuantityMany, QuantityOther}
+
+type Plurals struct {
+	XMLName      xml.Name
+	Key          string       `xml:"name,attr"`
+	Translatable string       `xml:"translatable,attr,omitempty"`
+	Items        []PluralItem `xml:"item"`
+}
+
+type PluralItem struct {
+	XMLName  xml.Name
+	Quantity string `xml:"quantity,attr"`
+	Value    string `xml:",innerxml"`
+}
+
+type StringArray struct {
+	XMLName      xml.Name
+	Key          string            `xml:"name,attr"`
+	Translatable string            `xml:"translatable,attr,omitempty"`
+	Items        []StringArrayItem `xml:"item"`
+}
+
+type StringArrayItem struct {
+	XMLName xml.Name
+	Value   string `xml:",innerxml"`
+}
+
 type AllResources struct {
 	existentResourcesPaths []string
 	stringKeys             map[string][]string
@@ -77,6 +123,26 @@ func (lr ListResources) CheckMissingTranslations() AllResources {
 
 			allResources.stringKeys[s.Key] = append(allResources.stringKeys[s.Key], r.Translation.Language)
 		}
+
+		for _, p := range r.Plurals {
+			if p.Translatable == "false" {
+				continue
+			}
+
+			// Plurals live in their own namespace (R.plurals), so a string and a
+			// plurals sharing the same name must not be mixed up
+			key := PluralsReportKey(p.Key)
+			allResources.stringKeys[key] = append(allResources.stringKeys[key], r.Translation.Language)
+		}
+
+		for _, a := range r.StringArrays {
+			if a.Translatable == "false" {
+				continue
+			}
+
+			key := StringArrayReportKey(a.Key)
+			allResources.stringKeys[key] = append(allResources.stringKeys[key], r.Translation.Language)
+		}
 	}
 
 	return allResources
@@ -170,11 +236,74 @@ func (r Resources) CreateOrSubstituteStringByKey(key string, value string) Resou
 	return r
 }
 
-// Marshal the updated Resources struct back to XML
+// Render the Resources struct as the new content of the XML file in path.
+// Everything of the current file that is not a resource changed in r is kept
+// byte by byte, a file that does not exist yet is rendered from scratch.
+func (r Resources) RenderXML(path string) ([]byte, error) {
+	source, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(source)) == 0 {
+		return EmptyDocument().Render(r), nil
+	}
+
+	document, err := ParseDocument(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return document.Render(r), nil
+}
+
+// Render r, whose resources with key from were renamed to the key to, as the
+// new content of the XML file in path. The renamed resources keep the tags
+// they have in the file, with every attribute besides the name.
+func (r Resources) RenderRenamedXML(path, from, to string) ([]byte, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := ParseDocument(source)
+	if err != nil {
+		return nil, err
+	}
+	document.RenameKey(from, to)
+
+	return document.Render(r), nil
+}
+
+// Unified diff between the XML file in path and the content r would write to
+// it, empty when nothing changes
+func (r Resources) DiffXML(path string) (string, error) {
+	output, err := r.RenderXML(path)
+	if err != nil {
+		return "", err
+	}
+
+	source, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	return UnifiedDiff(path, source, output)
+}
+
+// Unified diff of the file in path changing from before to after, empty when
+// nothing changes
+func UnifiedDiff(path string, before, after []byte) (string, error) {
+	// Line based edits, so unchanged lines are not shown as removed and added again
+	name := FindingPath(path)
+	edits := myers.ComputeEdits(string(before), string(after))
+	return udiff.ToUnified("a/"+name, "b/"+name, string(before), edits, udiff.DefaultContextLines)
+}
+
+// Render the updated Resources struct into the document of the XML file
 func (r Resources) UpdateResourcesToXMLFile(path string) error {
-	output, err := xml.MarshalIndent(r, "", "    ")
+	output, err := r.RenderXML(path)
 	if err != nil {
-		fmt.Printf("Error marshaling XML: %v\n", err)
+		fmt.Printf("Error rendering XML: %v\n", err)
 		return err
 	}
 
@@ -188,13 +317,37 @@ func (r Resources) UpdateResourcesToXMLFile(path string) error {
 	return nil
 }
 
+// Mark the string with the given key as translatable or not, translatable
+// strings have no translatable attribute
+func (r Resources) SetStringTranslatable(key string, translatable bool) Resources {
+	r.Strings = slices.Clone(r.Strings)
+	for i, s := range r.Strings {
+		if s.Key != key {
+			continue
+		}
+
+		r.Strings[i].Translatable = ""
+		if !translatable {
+			r.Strings[i].Translatable = "false"
+		}
+	}
+
+	return r
+}
+
 func (r Resources) IsSortedByKey() bool {
-	for i := range r.Strings {
+	return isSortedByKey(r.Strings, func(s String) string { return s.Key }) &&
+		isSortedByKey(r.Plurals, func(p Plurals) string { return p.Key }) &&
+		isSortedByKey(r.StringArrays, func(a StringArray) string { return a.Key })
+}
+
+func isSortedByKey[T any](items []T, key func(T) string) bool {
+	for i := range items {
 		if i == 0 {
 			continue
 		}
 
-		if r.Strings[i-1].Key > r.Strings[i].Key {
+		if key(items[i-1]) > key(items[i]) {
 			return false
 		}
 	}
@@ -210,4 +363,918 @@ func (r Resources) SortByKey() {
 	sort.SliceStable(r.Strings, func(i, j int) bool {
 		return r.Strings[i].Key < r.Strings[j].Key
 	})
+
+	sort.SliceStable(r.Plurals, func(i, j int) bool {
+		return r.Plurals[i].Key < r.Plurals[j].Key
+	})
+
+	sort.SliceStable(r.StringArrays, func(i, j int) bool {
+		return r.StringArrays[i].Key < r.StringArrays[j].Key
+	})
+}
+
+// Key used to report a plurals resource, distinguishing it from a string with the same name
+func PluralsReportKey(key string) string {
+	return "plurals/" + key
+}
+
+func (r Resources) ContainsPluralsByKey(key string) bool {
+	for _, p := range r.Plurals {
+		if p.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Resources) RemovePluralsByKey(key string) Resources {
+	r.Plurals = slices.DeleteFunc(r.Plurals, func(p Plurals) bool {
+		return p.Key == key
+	})
+	return r
+}
+
+// Key used to report a resource of the given kind, strings are reported by their plain key
+func ReportKey(kind, key string) string {
+	switch kind {
+	case KindPlurals:
+		return PluralsReportKey(key)
+	case KindStringArray:
+		return StringArrayReportKey(key)
+	}
+
+	return key
+}
+
+// Key used to report a string-array resource, distinguishing it from a string with the same name
+func StringArrayReportKey(key string) string {
+	return "string-array/" + key
+}
+
+func NewStringArray(key string, values []string) StringArray {
+	items := []StringArrayItem{}
+	for _, v := range values {
+		items = append(items, StringArrayItem{XMLName: xml.Name{Local: "item"}, Value: v})
+	}
+
+	return StringArray{
+		XMLName: xml.Name{Local: "string-array"},
+		Key:     key,
+		Items:   items,
+	}
+}
+
+func (a StringArray) Values() []string {
+	values := []string{}
+	for _, item := range a.Items {
+		values = append(values, item.Value)
+	}
+	return values
+}
+
+func (r Resources) AppendNewStringArray(newArray StringArray) Resources {
+	r.StringArrays = append(r.StringArrays, newArray)
+	return r
+}
+
+func (r Resources) AddNewStringArraySorted(newArray StringArray) Resources {
+	index := sort.Search(len(r.StringArrays), func(i int) bool {
+		return r.StringArrays[i].Key >= newArray.Key
+	})
+	r.StringArrays = slices.Insert(r.StringArrays, index, newArray)
+	return r
+}
+
+func (r Resources) ContainsStringArrayByKey(key string) bool {
+	for _, a := range r.StringArrays {
+		if a.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Resources) RemoveStringArrayByKey(key string) Resources {
+	r.StringArrays = slices.DeleteFunc(r.StringArrays, func(a StringArray) bool {
+		return a.Key == key
+	})
+	return r
+}
+
+func (r Resources) CreateOrSubstituteStringArrayByKey(key string, values []string) Resources {
+	newArray := NewStringArray(key, values)
+
+	for index, a := range r.StringArrays {
+		if a.Key == key {
+			r.StringArrays[index].Items = newArray.Items
+			return r
+		}
+	}
+
+	if r.IsSortedByKey() {
+		return r.AddNewStringArraySorted(newArray)
+	}
+
+	return r.AppendNewStringArray(newArray)
+}
+
+// Resources of source that are missing in r. Non translatable resources are
+// not expected in other locales and are ignored.
+func (r Resources) MissingResourcesFrom(source Resources) Resources {
+	missing := Resources{XMLName: r.XMLName, Translation: r.Translation}
+
+	for _, s := range source.Strings {
+		if s.Translatable != "false" && !r.ContainsStringByKey(s.Key) {
+			missing.Strings = append(missing.Strings, s)
+		}
+	}
+
+	for _, p := range source.Plurals {
+		if p.Translatable != "false" && !r.ContainsPluralsByKey(p.Key) {
+			missing.Plurals = append(missing.Plurals, p)
+		}
+	}
+
+	for _, a := range source.StringArrays {
+		if a.Translatable != "false" && !r.ContainsStringArrayByKey(a.Key) {
+			missing.StringArrays = append(missing.StringArrays, a)
+		}
+	}
+
+	return missing
+}
+
+// Resources of r without the ones whose key is excluded
+func (r Resources) WithoutKeys(excluded func(key string) bool) Resources {
+	filtered := Resources{XMLName: r.XMLName, Translation: r.Translation}
+
+	for _, s := range r.Strings {
+		if !excluded(s.Key) {
+			filtered.Strings = append(filtered.Strings, s)
+		}
+	}
+
+	for _, p := range r.Plurals {
+		if !excluded(p.Key) {
+			filtered.Plurals = append(filtered.Plurals, p)
+		}
+	}
+
+	for _, a := range r.StringArrays {
+		if !excluded(a.Key) {
+			filtered.StringArrays = append(filtered.StringArrays, a)
+		}
+	}
+
+	return filtered
+}
+
+// Resources of r with the ones of other added. When keepSorted is set and the
+// resources of a kind are sorted by key, they are inserted keeping them sorted,
+// otherwise they are appended.
+func (r Resources) AddResources(other Resources, keepSorted bool) Resources {
+	r.Strings = addByKey(r.Strings, other.Strings, keepSorted, func(s String) string { return s.Key })
+	r.Plurals = addByKey(r.Plurals, other.Plurals, keepSorted, func(p Plurals) string { return p.Key })
+	r.StringArrays = addByKey(r.StringArrays, other.StringArrays, keepSorted, func(a StringArray) string { return a.Key })
+
+	return r
+}
+
+func addByKey[T any](items, added []T, keepSorted bool, key func(T) string) []T {
+	sorted := keepSorted && isSortedByKey(items, key)
+	items = slices.Clone(items)
+
+	for _, item := range added {
+		index := len(items)
+		if sorted {
+			index = sort.Search(len(items), func(i int) bool { return key(items[i]) >= key(item) })
+		}
+		items = slices.Insert(items, index, item)
+	}
+
+	return items
+}
+
+// Rename the string, plurals and string-array with key from to the key to. When
+// keepSorted is set and the resources of a kind are sorted by key, the renamed
+// resource is moved to keep them sorted, otherwise it stays where it was.
+func (r Resources) RenameResourceByKey(from, to string, keepSorted bool) Resources {
+	r.Strings = renameByKey(r.Strings, from, to, keepSorted,
+		func(s String) string { return s.Key },
+		func(s String, key string) String { s.Key = key; return s })
+	r.Plurals = renameByKey(r.Plurals, from, to, keepSorted,
+		func(p Plurals) string { return p.Key },
+		func(p Plurals, key string) Plurals { p.Key = key; return p })
+	r.StringArrays = renameByKey(r.StringArrays, from, to, keepSorted,
+		func(a StringArray) string { return a.Key },
+		func(a StringArray, key string) StringArray { a.Key = key; return a })
+
+	return r
+}
+
+func renameByKey[T any](items []T, from, to string, keepSorted bool, key func(T) string, withKey func(T, string) T) []T {
+	index := slices.IndexFunc(items, func(item T) bool { return key(item) == from })
+	if index < 0 {
+		return items
+	}
+
+	sorted := keepSorted && isSortedByKey(items, key)
+	renamed := withKey(items[index], to)
+
+	items = slices.Delete(slices.Clone(items), index, index+1)
+	if sorted {
+		index = sort.Search(len(items), func(i int) bool { return key(items[i]) >= to })
+	}
+
+	return slices.Insert(items, index, renamed)
+}
+
+// Check if there is a string, plurals or string-array with the given key
+func (r Resources) ContainsResourceByKey(key string) bool {
+	return r.ContainsStringByKey(key) || r.ContainsPluralsByKey(key) || r.ContainsStringArrayByKey(key)
+}
+
+// Remove every string, plurals or string-array with the given key
+func (r Resources) RemoveResourceByKey(key string) Resources {
+	return r.RemoveStringByKey(key).RemovePluralsByKey(key).RemoveStringArrayByKey(key)
+}
+
+// Tag names of the resources mapped to Resources. Any other node of a
+// strings.xml file is kept untouched by Document.
+const (
+	KindString      = "string"
+	KindPlurals     = "plurals"
+	KindStringArray = "string-array"
+)
+
+var resourceKinds = []string{KindString, KindPlurals, KindStringArray}
+
+const defaultIndent = "    "
+
+// Document is a token level representation of a strings.xml file. The XML
+// declaration, comments, blank lines, attributes and unknown elements are kept
+// as they were read, so rendering a document only rewrites the resources that
+// actually changed.
+type Document struct {
+	source      []byte
+	head        []byte
+	nodes       []documentNode
+	tail        []byte
+	selfClosing bool
+	// Set when nodes were changed after the source was read
+	modified bool
+	// Nodes of another document the new resources are taken from, by kind
+	// and key
+	donors map[string]documentNode
+}
+
+// A child node of <resources> and the whitespace that precedes it
+type documentNode struct {
+	leading  []byte
+	raw      []byte
+	kind     string
+	key      string
+	start    xml.StartElement
+	startTag []byte
+	endTag   []byte
+	// Line of the start tag in the source, starting at 1
+	line int
+}
+
+// A resource element that can be rendered inside a Document
+type resourceElement interface {
+	kind() string
+	key() string
+	translatable() string
+	innerXML(indent string) string
+}
+
+func (s String) kind() string         { return KindString }
+func (s String) key() string          { return s.Key }
+func (s String) translatable() string { return s.Translatable }
+
+func (s String) innerXML(indent string) string {
+	return s.Value
+}
+
+func (p Plurals) kind() string         { return KindPlurals }
+func (p Plurals) key() string          { return p.Key }
+func (p Plurals) translatable() string { return p.Translatable }
+
+func (p Plurals) innerXML(indent string) string {
+	inner := ""
+	for _, item := range p.Items {
+		inner += fmt.Sprintf("\n%v%v<item quantity=\"%v\">%v</item>", indent, indent, escapeXMLAttr(item.Quantity), item.Value)
+	}
+
+	if inner == "" {
+		return ""
+	}
+
+	return inner + "\n" + indent
+}
+
+func (a StringArray) kind() string         { return KindStringArray }
+func (a StringArray) key() string          { return a.Key }
+func (a StringArray) translatable() string { return a.Translatable }
+
+func (a StringArray) innerXML(indent string) string {
+	inner := ""
+	for _, item := range a.Items {
+		inner += fmt.Sprintf("\n%v%v<item>%v</item>", indent, indent, item.Value)
+	}
+
+	if inner == "" {
+		return ""
+	}
+
+	return inner + "\n" + indent
+}
+
+func (r Resources) resourceElements(kind string) []resourceElement {
+	elements := []resourceElement{}
+
+	switch kind {
+	case KindString:
+		for _, s := range r.Strings {
+			elements = append(elements, s)
+		}
+	case KindPlurals:
+		for _, p := range r.Plurals {
+			elements = append(elements, p)
+		}
+	case KindStringArray:
+		for _, a := range r.StringArrays {
+			elements = append(elements, a)
+		}
+	}
+
+	return elements
+}
+
+func parseResourceElement(kind string, raw []byte) (resourceElement, error) {
+	switch kind {
+	case KindString:
+		var s String
+		err := xml.Unmarshal(raw, &s)
+		return s, err
+	case KindPlurals:
+		var p Plurals
+		err := xml.Unmarshal(raw, &p)
+		return p, err
+	case KindStringArray:
+		var a StringArray
+		err := xml.Unmarshal(raw, &a)
+		return a, err
+	}
+
+	return nil, fmt.Errorf("unknown resource kind %v", kind)
+}
+
+// Document of a strings.xml file without any resource
+func EmptyDocument() *Document {
+	document, _ := ParseDocument([]byte("<resources></resources>"))
+	return document
+}
+
+// Document of a new strings.xml file, with the XML declaration Android Studio
+// adds to resource files
+func NewDocument() *Document {
+	document, _ := ParseDocument([]byte("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources></resources>\n"))
+	return document
+}
+
+// Content of a new strings.xml file with the resources of r
+func (r Resources) RenderNewXML() []byte {
+	return NewDocument().Render(r)
+}
+
+// Render the document with the resources of r, taking the ones that are new
+// to the document from the document they were moved from. They keep their
+// original bytes, and the namespaces they use are declared in the root
+// element when it does not declare them yet.
+func (d *Document) RenderMoved(r Resources, from *Document) []byte {
+	moved := *d
+	moved.donors = map[string]documentNode{}
+
+	raw := []byte{}
+	for _, n := range from.nodes {
+		key := n.kind + "/" + n.key
+		if _, ok := moved.donors[key]; n.kind == "" || ok {
+			continue
+		}
+
+		moved.donors[key] = n
+		raw = append(raw, n.raw...)
+	}
+
+	declared := rootNamespaces(d.head)
+	namespaces := rootNamespaces(from.head)
+	for _, namespace := range slices.Sorted(maps.Keys(namespaces)) {
+		if _, ok := declared[namespace]; ok || !bytes.Contains(raw, []byte(namespace+":")) {
+			continue
+		}
+
+		declaration := fmt.Sprintf(" xmlns:%v=\"%v\"", namespace, escapeXMLAttr(namespaces[namespace]))
+
+		end := bytes.LastIndexByte(moved.head, '>')
+		if moved.head[end-1] == '/' {
+			end--
+		}
+		moved.head = slices.Concat(moved.head[:end], []byte(declaration), moved.head[end:])
+		moved.modified = true
+	}
+
+	return moved.Render(r)
+}
+
+// Namespaces declared in the <resources> start tag that ends head, by prefix
+func rootNamespaces(head []byte) map[string]string {
+	namespaces := map[string]string{}
+
+	decoder := xml.NewDecoder(bytes.NewReader(head))
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			return namespaces
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			for _, a := range start.Attr {
+				if a.Name.Space == "xmlns" {
+					namespaces[a.Name.Local] = a.Value
+				}
+			}
+			return namespaces
+		}
+	}
+}
+
+// Render r, whose resources were moved from the XML file in source, as the
+// new content of the XML file in path, which is created when it does not
+// exist yet. The moved resources are written as they are in source.
+func (r Resources) RenderMovedXML(path, source string) ([]byte, error) {
+	from, err := os.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDocument, err := ParseDocument(from)
+	if err != nil {
+		return nil, err
+	}
+
+	document := NewDocument()
+	if content, err := os.ReadFile(path); err == nil {
+		document, err = ParseDocument(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return document.RenderMoved(r, fromDocument), nil
+}
+
+// Split the content of a strings.xml file into the nodes of a Document
+func ParseDocument(source []byte) (*Document, error) {
+	document := &Document{source: source}
+
+	decoder := xml.NewDecoder(bytes.NewReader(source))
+
+	depth := 0
+	rootFound := false
+	rootClosed := false
+	lastEnd := int64(0)
+	nodeStart := int64(0)
+	var current documentNode
+
+	line := 1
+	lineOffset := int64(0)
+
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		end := decoder.InputOffset()
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+
+			if depth == 1 {
+				if rootFound || t.Name.Local != "resources" {
+					return nil, fmt.Errorf("expected a single <resources> root element")
+				}
+
+				rootFound = true
+				document.head = source[:end]
+				lastEnd = end
+			}
+
+			if depth == 2 {
+				line += bytes.Count(source[lineOffset:offset], []byte("\n"))
+				lineOffset = offset
+
+				nodeStart = offset
+				current = documentNode{
+					leading:  source[lastEnd:offset],
+					start:    t.Copy(),
+					startTag: source[offset:end],
+					line:     line,
+				}
+
+				if t.Name.Space == "" && slices.Contains(resourceKinds, t.Name.Local) {
+					current.kind = t.Name.Local
+					current.key = attrValue(t, "name")
+				}
+			}
+		case xml.EndElement:
+			depth--
+
+			if depth == 0 {
+				// A self-closing <resources/> does not advance the input for its end element
+				document.selfClosing = offset == end && end == int64(len(document.head))
+				document.tail = source[lastEnd:]
+				rootClosed = true
+			}
+
+			if depth == 1 {
+				current.raw = source[nodeStart:end]
+				current.endTag = source[offset:end]
+				document.nodes = append(document.nodes, current)
+				lastEnd = end
+			}
+		case xml.CharData:
+			// Text directly inside <resources> is kept as leading of the next node
+			continue
+		default:
+			if depth == 1 {
+				document.nodes = append(document.nodes, documentNode{
+					leading: source[lastEnd:offset],
+					raw:     source[offset:end],
+				})
+				lastEnd = end
+			}
+		}
+	}
+
+	if !rootFound || !rootClosed {
+		return nil, fmt.Errorf("missing <resources> element")
+	}
+
+	return document, nil
+}
+
+// Name attribute of a start tag, not preceded by a namespace prefix
+var nameAttrRegex = regexp.MustCompile(`\sname\s*=\s*("[^"]*"|'[^']*')`)
+
+// Rename the resources with key from to the key to. Only the value of their
+// name attribute changes, every other attribute is kept as it was written.
+func (d *Document) RenameKey(from, to string) {
+	for i, n := range d.nodes {
+		if n.kind == "" || n.key != from {
+			continue
+		}
+
+		match := nameAttrRegex.FindSubmatchIndex(n.startTag)
+		if match == nil {
+			continue
+		}
+
+		quote := n.startTag[match[2]]
+		startTag := slices.Concat(n.startTag[:match[2]], []byte{quote}, []byte(escapeXMLAttr(to)), []byte{quote}, n.startTag[match[3]:])
+
+		n.raw = slices.Concat(startTag, n.raw[len(n.startTag):])
+		n.startTag = startTag
+		n.key = to
+		n.start.Attr = slices.Clone(n.start.Attr)
+		for j, a := range n.start.Attr {
+			if a.Name.Space == "" && a.Name.Local == "name" {
+				n.start.Attr[j].Value = to
+			}
+		}
+
+		d.nodes[i] = n
+		d.modified = true
+	}
+}
+
+// Line of each resource of the document by its report key, e.g. "plurals/songs"
+func (d *Document) Lines() map[string]int {
+	lines := map[string]int{}
+
+	for _, n := range d.nodes {
+		if n.kind == "" {
+			continue
+		}
+
+		key := ReportKey(n.kind, n.key)
+		if _, ok := lines[key]; !ok {
+			lines[key] = n.line
+		}
+	}
+
+	return lines
+}
+
+// Line of each resource of a strings.xml file by its report key
+func ResourceLines(path string) (map[string]int, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := ParseDocument(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return document.Lines(), nil
+}
+
+// Comment right above each resource of the document by its report key, the
+// notes developers leave for translators
+func (d *Document) Comments() map[string]string {
+	comments := map[string]string{}
+
+	for i, n := range d.nodes {
+		if n.kind == "" || i == 0 {
+			continue
+		}
+
+		previous := d.nodes[i-1]
+		if previous.kind != "" || !bytes.HasPrefix(previous.raw, []byte("<!--")) || bytes.Count(n.leading, []byte("\n")) > 1 {
+			continue
+		}
+
+		comment := strings.TrimSuffix(strings.TrimPrefix(string(previous.raw), "<!--"), "-->")
+		comments[ReportKey(n.kind, n.key)] = strings.TrimSpace(comment)
+	}
+
+	return comments
+}
+
+// Comment right above each resource of a strings.xml file by its report key
+func ResourceComments(path string) (map[string]string, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := ParseDocument(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return document.Comments(), nil
+}
+
+func attrValue(start xml.StartElement, name string) string {
+	for _, a := range start.Attr {
+		if a.Name.Space == "" && a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// Indentation used by the children of <resources>
+func (d *Document) indent() string {
+	for _, n := range d.nodes {
+		index := bytes.LastIndexByte(n.leading, '\n')
+		if index >= 0 {
+			return string(n.leading[index+1:])
+		}
+	}
+
+	return defaultIndent
+}
+
+// A node in the output of Render, tagged with the resource it holds
+type renderedNode struct {
+	leading []byte
+	raw     []byte
+	kind    string
+	index   int
+}
+
+// Render the document with the resources of r. Unknown nodes stay where they
+// are, resources removed from r are dropped, changed ones are rewritten in
+// place, reordered ones swap places with each other and new ones are inserted
+// after the resource that precedes them in r.
+func (d *Document) Render(r Resources) []byte {
+	indent := d.indent()
+	changed := d.modified
+
+	// Match each resource of r with the first unmatched node with the same key
+	available := map[string][]int{}
+	for i, n := range d.nodes {
+		if n.kind != "" {
+			available[n.kind+"/"+n.key] = append(available[n.kind+"/"+n.key], i)
+		}
+	}
+
+	sources := map[string][]int{}
+	for _, kind := range resourceKinds {
+		for _, e := range r.resourceElements(kind) {
+			source := -1
+			if nodes := available[kind+"/"+e.key()]; len(nodes) > 0 {
+				source = nodes[0]
+				available[kind+"/"+e.key()] = nodes[1:]
+			}
+			sources[kind] = append(sources[kind], source)
+		}
+	}
+
+	// Nodes of matched resources are slots filled in the order of r
+	type slot struct {
+		index  int
+		source int
+	}
+	slots := map[int]slot{}
+	for _, kind := range resourceKinds {
+		positions := []int{}
+		for i, n := range d.nodes {
+			if n.kind == kind && !slices.Contains(available[kind+"/"+n.key], i) {
+				positions = append(positions, i)
+			}
+		}
+
+		next := 0
+		for index, source := range sources[kind] {
+			if source >= 0 {
+				slots[positions[next]] = slot{index: index, source: source}
+				next++
+			}
+		}
+	}
+
+	output := []renderedNode{}
+	for i, n := range d.nodes {
+		if n.kind == "" {
+			output = append(output, renderedNode{leading: n.leading, raw: n.raw, index: -1})
+			continue
+		}
+
+		s, ok := slots[i]
+		if !ok {
+			changed = true
+			continue
+		}
+
+		element := r.resourceElements(n.kind)[s.index]
+		raw := d.nodes[s.source].render(element, indent)
+		if s.source != i || !bytes.Equal(raw, n.raw) {
+			changed = true
+		}
+
+		output = append(output, renderedNode{leading: n.leading, raw: raw, kind: n.kind, index: s.index})
+	}
+
+	for _, kind := range resourceKinds {
+		for index, element := range r.resourceElements(kind) {
+			if sources[kind][index] >= 0 {
+				continue
+			}
+
+			changed = true
+			node := renderedNode{
+				leading: []byte("\n" + indent),
+				raw:     d.donors[kind+"/"+element.key()].render(element, indent),
+				kind:    kind,
+				index:   index,
+			}
+			output = slices.Insert(output, insertPosition(output, kind, index), node)
+		}
+	}
+
+	if !changed {
+		return d.source
+	}
+
+	head := d.head
+	tail := d.tail
+	if d.selfClosing && len(output) > 0 {
+		// Open <resources/> as <resources></resources> to hold the new nodes
+		open := bytes.TrimRight(head[:len(head)-len("/>")], " \t\r\n")
+		head = append(slices.Clone(open), '>')
+		tail = append([]byte("\n</resources>"), tail...)
+	} else if len(d.nodes) == 0 && len(output) > 0 {
+		closingIndentation := tail[:bytes.IndexByte(tail, '<')]
+		if !bytes.ContainsRune(closingIndentation, '\n') {
+			tail = append([]byte("\n"), tail...)
+		}
+	}
+
+	var buffer bytes.Buffer
+	buffer.Write(head)
+	for _, n := range output {
+		buffer.Write(n.leading)
+		buffer.Write(n.raw)
+	}
+	buffer.Write(tail)
+
+	return buffer.Bytes()
+}
+
+// Position of a new resource: right after the resource that precedes it in r,
+// before the first resource of the same kind or after the last known resource
+func insertPosition(output []renderedNode, kind string, index int) int {
+	if index > 0 {
+		for i, n := range output {
+			if n.kind == kind && n.index == index-1 {
+				return i + 1
+			}
+		}
+	}
+
+	for i, n := range output {
+		if n.kind == kind {
+			return i
+		}
+	}
+
+	for i := len(output) - 1; i >= 0; i-- {
+		if output[i].kind != "" {
+			return i + 1
+		}
+	}
+
+	return len(output)
+}
+
+// Render element reusing the bytes of the node when nothing has changed. The
+// original start and end tags are kept when possible to preserve attributes
+// that are not mapped to Resources.
+func (n documentNode) render(element resourceElement, indent string) []byte {
+	if n.raw == nil {
+		start := xml.StartElement{
+			Name: xml.Name{Local: element.kind()},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "name"}, Value: element.key()}},
+		}
+
+		return []byte(startTag(start, element.translatable()) + element.innerXML(indent) + "</" + element.kind() + ">")
+	}
+
+	original, err := parseResourceElement(n.kind, n.raw)
+	if err == nil && bytes.Equal(freshRender(original, indent), freshRender(element, indent)) {
+		return n.raw
+	}
+
+	// Self-closing elements and changed attributes need new tags
+	if len(n.endTag) == 0 || err != nil || original.translatable() != element.translatable() {
+		return []byte(startTag(n.start, element.translatable()) + element.innerXML(indent) + "</" + qualifiedName(n.start.Name) + ">")
+	}
+
+	return []byte(string(n.startTag) + element.innerXML(indent) + string(n.endTag))
+}
+
+func freshRender(element resourceElement, indent string) []byte {
+	return documentNode{}.render(element, indent)
+}
+
+// Build a start tag keeping every attribute of start but translatable
+func startTag(start xml.StartElement, translatable string) string {
+	tag := "<" + qualifiedName(start.Name)
+
+	found := false
+	for _, a := range start.Attr {
+		if a.Name.Space == "" && a.Name.Local == "translatable" {
+			found = true
+			if translatable == "" {
+				continue
+			}
+			a.Value = translatable
+		}
+
+		tag += fmt.Sprintf(" %v=\"%v\"", qualifiedName(a.Name), escapeXMLAttr(a.Value))
+	}
+
+	if !found && translatable != "" {
+		tag += fmt.Sprintf(" translatable=\"%v\"", escapeXMLAttr(translatable))
+	}
+
+	return tag + ">"
+}
+
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
+
+func escapeXMLAttr(value string) string {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(value))
+	return buffer.String()
 }
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -1074,3 +1075,642 @@ func TestCheckMissingTranslationsSkipNonTranslatable(t *testing.T) {
 		})
 	}
 }
+
+func TestPluralsRoundTrip(t *testing.T) {
+	xmlContent := `<?xml version="1.0" encoding="UTF-8"?>
+<resources>
+    <string name="app_name">Test App</string>
+    <plurals name="songs">
+        <item quantity="one">%d song</item>
+        <item quantity="other">%d songs</item>
+    </plurals>
+</resources>`
+
+	path := filepath.Join(t.TempDir(), "strings.xml")
+	if err := os.WriteFile(path, []byte(xmlContent), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	r, err := GetResourcesFromPathXML(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedPlurals := []Plurals{
+		{
+			XMLName: xml.Name{Local: "plurals"},
+			Key:     "songs",
+			Items: []PluralItem{
+				{XMLName: xml.Name{Local: "item"}, Quantity: QuantityOne, Value: "%d song"},
+				{XMLName: xml.Name{Local: "item"}, Quantity: QuantityOther, Value: "%d songs"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(r.Plurals, expectedPlurals) {
+		t.Errorf("Plurals = %v, want %v", r.Plurals, expectedPlurals)
+	}
+
+	if err := r.UpdateResourcesToXMLFile(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+
+	if string(content) != xmlContent {
+		t.Errorf("File content does not match expected output.\nGot:\n%s\nWant:\n%s", content, xmlContent)
+	}
+}
+
+func TestSortByKeyWithPlurals(t *testing.T) {
+	r := Resources{
+		Strings: []String{{Key: "b"}, {Key: "a"}},
+		Plurals: []Plurals{{Key: "d"}, {Key: "c"}},
+	}
+
+	if r.IsSortedByKey() {
+		t.Errorf("IsSortedByKey() = true, want false")
+	}
+
+	r.SortByKey()
+
+	expected := Resources{
+		Strings: []String{{Key: "a"}, {Key: "b"}},
+		Plurals: []Plurals{{Key: "c"}, {Key: "d"}},
+	}
+	if !reflect.DeepEqual(r, expected) {
+		t.Errorf("SortByKey() = %v, want %v", r, expected)
+	}
+
+	unsortedPlurals := Resources{
+		Strings: []String{{Key: "a"}, {Key: "b"}},
+		Plurals: []Plurals{{Key: "d"}, {Key: "c"}},
+	}
+	if unsortedPlurals.IsSortedByKey() {
+		t.Errorf("IsSortedByKey() = true, want false when only plurals are unsorted")
+	}
+}
+
+func TestCheckMissingTranslationsWithPlurals(t *testing.T) {
+	listResources := ListResources{
+		{
+			Translation: Translation{Language: "en"},
+			Strings:     []String{{Key: "songs"}},
+			Plurals:     []Plurals{{Key: "songs"}, {Key: "albums", Translatable: "false"}},
+		},
+		{
+			Translation: Translation{Language: "fr"},
+			Strings:     []String{{Key: "songs"}},
+		},
+	}
+
+	want := AllResources{
+		existentResourcesPaths: []string{"en", "fr"},
+		stringKeys: map[string][]string{
+			"songs":         {"en", "fr"},
+			"plurals/songs": {"en"},
+		},
+	}
+
+	got := listResources.CheckMissingTranslations()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckMissingTranslations() = %v, want %v", got, want)
+	}
+
+	relatory := got.CheckMissingTranslationsRelatory()
+	expected := "\nFound 1 possible missing translations:\n\tplurals/songs:\n\t\tDEFINED IN: [en]\n\t\tMISSING FROM: [fr]\n"
+	if relatory != expected {
+		t.Errorf("CheckMissingTranslationsRelatory() = %v, want %v", relatory, expected)
+	}
+}
+
+func TestStringArrayRoundTrip(t *testing.T) {
+	xmlContent := `<resources>
+    <string name="app_name">Test App</string>
+    <string-array name="planets">
+        <item>Mercury</item>
+        <item>Venus</item>
+    </string-array>
+</resources>`
+
+	path := filepath.Join(t.TempDir(), "strings.xml")
+	if err := os.WriteFile(path, []byte(xmlContent), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	r, err := GetResourcesFromPathXML(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedArrays := []StringArray{
+		{
+			XMLName: xml.Name{Local: "string-array"},
+			Key:     "planets",
+			Items: []StringArrayItem{
+				{XMLName: xml.Name{Local: "item"}, Value: "Mercury"},
+				{XMLName: xml.Name{Local: "item"}, Value: "Venus"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(r.StringArrays, expectedArrays) {
+		t.Errorf("StringArrays = %v, want %v", r.StringArrays, expectedArrays)
+	}
+
+	if err := r.UpdateResourcesToXMLFile(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+
+	if string(content) != xmlContent {
+		t.Errorf("File content does not match expected output.\nGot:\n%s\nWant:\n%s", content, xmlContent)
+	}
+}
+
+func TestCreateOrSubstituteStringArrayByKey(t *testing.T) {
+	testCases := []struct {
+		name             string
+		initialResource  Resources
+		key              string
+		values           []string
+		expectedResource Resources
+	}{
+		{
+			name:            "Add to empty Resources",
+			initialResource: Resources{},
+			key:             "planets",
+			values:          []string{"Mercury", "Venus"},
+			expectedResource: Resources{
+				StringArrays: []StringArray{NewStringArray("planets", []string{"Mercury", "Venus"})},
+			},
+		},
+		{
+			name: "Add sorted into a sorted Resources",
+			initialResource: Resources{
+				StringArrays: []StringArray{{Key: "a"}, {Key: "c"}},
+			},
+			key:    "b",
+			values: []string{"B"},
+			expectedResource: Resources{
+				StringArrays: []StringArray{{Key: "a"}, NewStringArray("b", []string{"B"}), {Key: "c"}},
+			},
+		},
+		{
+			name: "Append into an unsorted Resources",
+			initialResource: Resources{
+				StringArrays: []StringArray{{Key: "c"}, {Key: "a"}},
+			},
+			key:    "b",
+			values: []string{"B"},
+			expectedResource: Resources{
+				StringArrays: []StringArray{{Key: "c"}, {Key: "a"}, NewStringArray("b", []string{"B"})},
+			},
+		},
+		{
+			name: "Substitute items of an existing key",
+			initialResource: Resources{
+				StringArrays: []StringArray{NewStringArray("planets", []string{"Mercury"})},
+			},
+			key:    "planets",
+			values: []string{"Mercurio", "Venus"},
+			expectedResource: Resources{
+				StringArrays: []StringArray{NewStringArray("planets", []string{"Mercurio", "Venus"})},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.initialResource.CreateOrSubstituteStringArrayByKey(tc.key, tc.values)
+
+			if !reflect.DeepEqual(result, tc.expectedResource) {
+				t.Errorf("CreateOrSubstituteStringArrayByKey() = %v, want %v", result, tc.expectedResource)
+			}
+		})
+	}
+}
+
+func TestRemoveResourceByKey(t *testing.T) {
+	r := Resources{
+		Strings:      []String{{Key: "a"}, {Key: "b"}},
+		Plurals:      []Plurals{{Key: "a"}, {Key: "c"}},
+		StringArrays: []StringArray{{Key: "a"}, {Key: "d"}},
+	}
+
+	if !r.ContainsResourceByKey("d") {
+		t.Errorf("ContainsResourceByKey(\"d\") = false, want true")
+	}
+	if r.ContainsResourceByKey("e") {
+		t.Errorf("ContainsResourceByKey(\"e\") = true, want false")
+	}
+
+	result := r.RemoveResourceByKey("a")
+
+	expected := Resources{
+		Strings:      []String{{Key: "b"}},
+		Plurals:      []Plurals{{Key: "c"}},
+		StringArrays: []StringArray{{Key: "d"}},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("RemoveResourceByKey() = %v, want %v", result, expected)
+	}
+}
+
+func TestCheckMissingTranslationsWithStringArrays(t *testing.T) {
+	listResources := ListResources{
+		{
+			Translation:  Translation{Language: "en"},
+			StringArrays: []StringArray{{Key: "planets"}, {Key: "codes", Translatable: "false"}},
+		},
+		{
+			Translation: Translation{Language: "fr"},
+		},
+	}
+
+	want := AllResources{
+		existentResourcesPaths: []string{"en", "fr"},
+		stringKeys: map[string][]string{
+			"string-array/planets": {"en"},
+		},
+	}
+
+	got := listResources.CheckMissingTranslations()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckMissingTranslations() = %v, want %v", got, want)
+	}
+}
+
+const documentXML = `<?xml version="1.0" encoding="utf-8"?>
+<resources xmlns:tools="http://schemas.android.com/tools" xmlns:xliff="urn:oasis:names:tc:xliff:document:1.2">
+
+    <!-- Onboarding -->
+    <string name="welcome" tools:ignore="MissingTranslation">Welcome <xliff:g id="name">%1$s</xliff:g></string>
+    <string name="about" formatted="false">About &amp; more</string>
+    <dimen name="margin">16dp</dimen>
+
+    <!-- Lists -->
+    <plurals name="songs">
+        <item quantity="one">%d song</item>
+        <item quantity="other">%d songs</item>
+    </plurals>
+    <string name="empty"/>
+</resources>
+`
+
+func loadDocumentResources(t *testing.T, content string) (Resources, string) {
+	path := filepath.Join(t.TempDir(), "strings.xml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	r, err := GetResourcesFromPathXML(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return r, path
+}
+
+func TestRenderXML(t *testing.T) {
+	testCases := []struct {
+		name           string
+		content        string
+		update         func(r Resources) Resources
+		expectedOutput string
+	}{
+		{
+			name:           "No-op keeps the file byte-identical",
+			content:        documentXML,
+			update:         func(r Resources) Resources { return r },
+			expectedOutput: documentXML,
+		},
+		{
+			name:    "Substitute keeps attributes not mapped to Resources",
+			content: documentXML,
+			update: func(r Resources) Resources {
+				return r.CreateOrSubstituteStringByKey("about", "About us")
+			},
+			expectedOutput: strings.Replace(documentXML, `formatted="false">About &amp; more<`, `formatted="false">About us<`, 1),
+		},
+		{
+			name:    "Remove drops only the element and its indentation",
+			content: documentXML,
+			update: func(r Resources) Resources {
+				return r.RemoveStringByKey("about")
+			},
+			expectedOutput: strings.Replace(documentXML, "\n    <string name=\"about\" formatted=\"false\">About &amp; more</string>", "", 1),
+		},
+		{
+			name:    "Add sorted inserts after the preceding key",
+			content: "<resources>\n    <!-- Keep -->\n    <string name=\"a\">A</string>\n    <string name=\"c\">C</string>\n</resources>\n",
+			update: func(r Resources) Resources {
+				return r.AddNewStringSorted(String{Key: "b", Value: "B"})
+			},
+			expectedOutput: "<resources>\n    <!-- Keep -->\n    <string name=\"a\">A</string>\n    <string name=\"b\">B</string>\n    <string name=\"c\">C</string>\n</resources>\n",
+		},
+		{
+			name:    "Sort moves resources and keeps comments and unknown elements in place",
+			content: "<resources>\n    <!-- Section -->\n    <string name=\"b\">B</string>\n    <color name=\"red\">#f00</color>\n    <string name=\"a\">A</string>\n</resources>",
+			update: func(r Resources) Resources {
+				r.SortByKey()
+				return r
+			},
+			expectedOutput: "<resources>\n    <!-- Section -->\n    <string name=\"a\">A</string>\n    <color name=\"red\">#f00</color>\n    <string name=\"b\">B</string>\n</resources>",
+		},
+		{
+			name:    "Changed translatable rewrites the start tag",
+			content: "<resources>\n    <string name=\"a\" tools:ignore=\"Typos\">A</string>\n</resources>",
+			update: func(r Resources) Resources {
+				r.Strings[0].Translatable = "false"
+				return r
+			},
+			expectedOutput: "<resources>\n    <string name=\"a\" tools:ignore=\"Typos\" translatable=\"false\">A</string>\n</resources>",
+		},
+		{
+			name:    "Changed plurals are re-indented",
+			content: "<resources>\n  <plurals name=\"songs\">\n    <item quantity=\"other\">%d songs</item>\n  </plurals>\n</resources>",
+			update: func(r Resources) Resources {
+				r.Plurals[0].Items = append(r.Plurals[0].Items, PluralItem{Quantity: QuantityOne, Value: "%d song"})
+				return r
+			},
+			expectedOutput: "<resources>\n  <plurals name=\"songs\">\n    <item quantity=\"other\">%d songs</item>\n    <item quantity=\"one\">%d song</item>\n  </plurals>\n</resources>",
+		},
+		{
+			name:    "Self-closing resources is opened to add a string",
+			content: "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources/>\n",
+			update: func(r Resources) Resources {
+				return r.AppendNewString(String{Key: "a", Value: "A"})
+			},
+			expectedOutput: "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n    <string name=\"a\">A</string>\n</resources>\n",
+		},
+		{
+			name:    "New kinds are added after the last resource",
+			content: "<resources>\n    <string name=\"a\">A</string>\n    <!-- End -->\n</resources>",
+			update: func(r Resources) Resources {
+				return r.AppendNewStringArray(NewStringArray("planets", []string{"Mercury"}))
+			},
+			expectedOutput: "<resources>\n    <string name=\"a\">A</string>\n    <string-array name=\"planets\">\n        <item>Mercury</item>\n    </string-array>\n    <!-- End -->\n</resources>",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, path := loadDocumentResources(t, tc.content)
+
+			if err := tc.update(r).UpdateResourcesToXMLFile(path); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+
+			if string(content) != tc.expectedOutput {
+				t.Errorf("File content does not match expected output.\nGot:\n%s\nWant:\n%s", content, tc.expectedOutput)
+			}
+		})
+	}
+}
+
+func TestRenderXMLReadErrors(t *testing.T) {
+	r := Resources{Strings: []String{{Key: "a", Value: "A"}}}
+
+	t.Run("Missing file is rendered from scratch", func(t *testing.T) {
+		got, err := r.RenderXML(filepath.Join(t.TempDir(), "strings.xml"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := "<resources>\n    <string name=\"a\">A</string>\n</resources>"
+		if string(got) != expected {
+			t.Errorf("RenderXML() = %q, want %q", got, expected)
+		}
+	})
+
+	t.Run("Unreadable file", func(t *testing.T) {
+		// A directory cannot be read as a file, whatever the permissions of the user
+		if _, err := r.RenderXML(t.TempDir()); err == nil {
+			t.Errorf("Expected an error, but got none")
+		}
+	})
+}
+
+func TestParseDocumentErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{name: "Not XML", content: "This is not valid XML content"},
+		{name: "Wrong root", content: "<manifest></manifest>"},
+		{name: "Unclosed root", content: "<resources><string name=\"a\">A</string>"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParseDocument([]byte(tc.content)); err == nil {
+				t.Errorf("Expected an error, but got none")
+			}
+		})
+	}
+}
+
+func TestMissingResourcesFrom(t *testing.T) {
+	source := Resources{
+		Strings:      []String{{Key: "a"}, {Key: "b"}, {Key: "c", Translatable: "false"}},
+		Plurals:      []Plurals{{Key: "songs"}},
+		StringArrays: []StringArray{{Key: "planets"}, {Key: "codes", Translatable: "false"}},
+	}
+
+	r := Resources{
+		Strings: []String{{Key: "a"}},
+		Plurals: []Plurals{{Key: "songs"}},
+	}
+
+	expected := Resources{
+		Strings:      []String{{Key: "b"}},
+		StringArrays: []StringArray{{Key: "planets"}},
+	}
+
+	got := r.MissingResourcesFrom(source)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("MissingResourcesFrom() = %v, want %v", got, expected)
+	}
+}
+
+func TestDiffXML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "strings.xml")
+	os.WriteFile(path, []byte("<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\">B</string>\n</resources>\n"), 0o644)
+
+	r, err := GetResourcesFromPathXML(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	unchanged, err := r.DiffXML(path)
+	if err != nil || unchanged != "" {
+		t.Errorf("DiffXML() = %q, %v, want an empty diff", unchanged, err)
+	}
+
+	got, err := r.RemoveStringByKey("b").DiffXML(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	name := FindingPath(path)
+	expected := "--- a/" + name + "\n+++ b/" + name + "\n@@ -1,4 +1,3 @@\n <resources>\n     <string name=\"a\">A</string>\n-    <string name=\"b\">B</string>\n </resources>\n"
+	if got != expected {
+		t.Errorf("DiffXML() = %q, want %q", got, expected)
+	}
+}
+
+func TestRenameResourceByKey(t *testing.T) {
+	testCases := []struct {
+		name       string
+		resources  Resources
+		from       string
+		to         string
+		keepSorted bool
+		expected   Resources
+	}{
+		{
+			name:       "Sorted strings",
+			resources:  Resources{Strings: []String{{Key: "a"}, {Key: "b"}, {Key: "d"}}},
+			from:       "b",
+			to:         "c",
+			keepSorted: true,
+			expected:   Resources{Strings: []String{{Key: "a"}, {Key: "c"}, {Key: "d"}}},
+		},
+		{
+			name:       "Sorted strings moved",
+			resources:  Resources{Strings: []String{{Key: "a", Value: "A"}, {Key: "b"}, {Key: "d"}}},
+			from:       "a",
+			to:         "z",
+			keepSorted: true,
+			expected:   Resources{Strings: []String{{Key: "b"}, {Key: "d"}, {Key: "z", Value: "A"}}},
+		},
+		{
+			name:       "Sort policy none",
+			resources:  Resources{Strings: []String{{Key: "a", Value: "A"}, {Key: "b"}, {Key: "d"}}},
+			from:       "a",
+			to:         "z",
+			keepSorted: false,
+			expected:   Resources{Strings: []String{{Key: "z", Value: "A"}, {Key: "b"}, {Key: "d"}}},
+		},
+		{
+			name:       "Unsorted plurals and string-arrays",
+			resources:  Resources{Plurals: []Plurals{{Key: "d"}, {Key: "a"}}, StringArrays: []StringArray{{Key: "a"}}},
+			from:       "a",
+			to:         "z",
+			keepSorted: true,
+			expected:   Resources{Plurals: []Plurals{{Key: "d"}, {Key: "z"}}, StringArrays: []StringArray{{Key: "z"}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.resources.RenameResourceByKey(tc.from, tc.to, tc.keepSorted)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("RenameResourceByKey() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAddResources(t *testing.T) {
+	added := Resources{Strings: []String{{Key: "b"}, {Key: "d"}}, Plurals: []Plurals{{Key: "songs"}}}
+
+	testCases := []struct {
+		name       string
+		resources  Resources
+		keepSorted bool
+		expected   Resources
+	}{
+		{
+			name:       "Sorted",
+			resources:  Resources{Strings: []String{{Key: "a"}, {Key: "c"}}},
+			keepSorted: true,
+			expected:   Resources{Strings: []String{{Key: "a"}, {Key: "b"}, {Key: "c"}, {Key: "d"}}, Plurals: []Plurals{{Key: "songs"}}},
+		},
+		{
+			name:       "Unsorted",
+			resources:  Resources{Strings: []String{{Key: "c"}, {Key: "a"}}},
+			keepSorted: true,
+			expected:   Resources{Strings: []String{{Key: "c"}, {Key: "a"}, {Key: "b"}, {Key: "d"}}, Plurals: []Plurals{{Key: "songs"}}},
+		},
+		{
+			name:       "Sort policy none",
+			resources:  Resources{Strings: []String{{Key: "a"}, {Key: "c"}}},
+			keepSorted: false,
+			expected:   Resources{Strings: []String{{Key: "a"}, {Key: "c"}, {Key: "b"}, {Key: "d"}}, Plurals: []Plurals{{Key: "songs"}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.resources.AddResources(added, tc.keepSorted)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("AddResources() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRenderNewXML(t *testing.T) {
+	r := Resources{Strings: []String{{Key: "a", Value: "A"}}}
+
+	expected := "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n    <string name=\"a\">A</string>\n</resources>\n"
+	if got := string(r.RenderNewXML()); got != expected {
+		t.Errorf("RenderNewXML() = %q, want %q", got, expected)
+	}
+}
+
+func TestSetStringTranslatable(t *testing.T) {
+	r := Resources{Strings: []String{{Key: "a"}, {Key: "b", Translatable: "false"}}}
+
+	got := r.SetStringTranslatable("a", false).SetStringTranslatable("b", true)
+
+	expected := Resources{Strings: []String{{Key: "a", Translatable: "false"}, {Key: "b"}}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("SetStringTranslatable() = %v, want %v", got, expected)
+	}
+
+	if r.Strings[0].Translatable != "" {
+		t.Errorf("SetStringTranslatable() changed the original resources")
+	}
+}
+
+func TestDocumentComments(t *testing.T) {
+	source := []byte(`<resources>
+    <!-- Title of the home screen -->
+    <string name="title">Title</string>
+    <!-- Detached comment -->
+
+    <string name="subtitle">Subtitle</string>
+    <string name="body">Body</string>
+    <!--Plural of titles-->
+    <plurals name="title">
+        <item quantity="other">Titles</item>
+    </plurals>
+</resources>
+`)
+
+	document, err := ParseDocument(source)
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"title":                   "Title of the home screen",
+		PluralsReportKey("title"): "Plural of titles",
+	}
+	if got := document.Comments(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Comments() = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	moveKeys []string
+	moveFrom string
+	moveTo   string
+	dryRunM  bool
+)
+
+func init() {
+	rootCmd.AddCommand(moveCmd)
+	moveCmd.Flags().StringSliceVarP(&moveKeys, "key", "k", []string{}, "Keys of the resources to move, comma separated or repeated")
+	moveCmd.Flags().StringVar(&moveFrom, "from", "", "Gradle module (e.g. :app) or resource directory the resources are moved from")
+	moveCmd.Flags().StringVar(&moveTo, "to", "", "Gradle module (e.g. :feature:login) or resource directory the resources are moved to")
+	moveCmd.Flags().BoolVar(&dryRunM, "dry-run", false, "Print the diff of the files instead of changing them")
+}
+
+var moveCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Move keys from a resource directory to another one, in every locale",
+	RunE:  runMoveCmd,
+}
+
+func runMoveCmd(cmd *cobra.Command, args []string) error {
+	err := internal.BlockIfNotAndroidProject()
+	if err != nil {
+		return err
+	}
+
+	err = loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if len(moveKeys) == 0 {
+		return fmt.Errorf("you need to pass the keys through --key flag to use this command")
+	}
+	if moveFrom == "" || moveTo == "" {
+		return fmt.Errorf("you need to pass the resource directories through --from and --to flags to use this command")
+	}
+
+	fromDir, err := sourceResDirectory(moveFrom)
+	if err != nil {
+		return err
+	}
+
+	toDir, err := destinationResDirectory(moveTo)
+	if err != nil {
+		return err
+	}
+
+	if filepath.Clean(fromDir) == filepath.Clean(toDir) {
+		return fmt.Errorf("--from and --to are the same resource directory %v", fromDir)
+	}
+
+	translations, err := internal.GetTranslationsFromResourceDirectory(fromDir)
+	if err != nil {
+		return err
+	}
+
+	isMoved := func(key string) bool { return slices.Contains(moveKeys, key) }
+	isKept := func(key string) bool { return !isMoved(key) }
+
+	changes := internal.NewFileChanges()
+	found := map[string][]string{}
+
+	for _, t := range translations {
+		r, err := internal.GetResourcesFromPathXML(t.Path)
+		if err != nil {
+			return err
+		}
+
+		moved := r.WithoutKeys(isKept)
+		if len(moved.Strings)+len(moved.Plurals)+len(moved.StringArrays) == 0 {
+			continue
+		}
+
+		recordKinds(found, moved)
+
+		// Same values-xx directory in the destination, created when missing
+		destination := filepath.Join(toDir, filepath.Base(filepath.Dir(t.Path)), "strings.xml")
+
+		d := internal.Resources{}
+		if _, err := os.Stat(destination); err == nil {
+			d, err = internal.GetResourcesFromPathXML(destination)
+			if err != nil {
+				return err
+			}
+
+			for _, key := range moveKeys {
+				if d.ContainsResourceByKey(key) {
+					return fmt.Errorf("key <%v> already exists in %v", key, destination)
+				}
+			}
+		}
+
+		output, err := d.AddResources(moved, projectConfig.SortsByKey()).RenderMovedXML(destination, t.Path)
+		if err != nil {
+			return err
+		}
+
+		err = changes.Set(destination, output)
+		if err != nil {
+			return err
+		}
+
+		output, err = r.WithoutKeys(isMoved).RenderXML(t.Path)
+		if err != nil {
+			return err
+		}
+
+		err = changes.Set(t.Path, output)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range moveKeys {
+		if len(found[key]) == 0 {
+			return fmt.Errorf("key <%v> not found in %v", key, fromDir)
+		}
+	}
+
+	invisible, err := invisibleReferences(found, internal.ModuleOfPath(toDir))
+	if err != nil {
+		return err
+	}
+
+	if dryRunM {
+		diff, err := changes.Diff()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), diff)
+		fmt.Fprintf(os.Stderr, "%v files would be changed, run without --dry-run to change them\n", len(changes.Paths()))
+	} else {
+		err = changes.Write()
+		if err != nil {
+			return err
+		}
+
+		for _, p := range changes.Paths() {
+			fmt.Fprintf(cmd.OutOrStdout(), "Updated %v\n", p)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Moved %v keys from %v to %v\n", len(moveKeys), fromDir, toDir)
+	}
+
+	if len(invisible) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nFound %v references in modules that do not depend on %v and will no longer see the resources:\n", len(invisible), internal.ModuleOfPath(toDir))
+		for _, reference := range invisible {
+			fmt.Fprintf(cmd.OutOrStdout(), "\t%v\n", reference)
+		}
+	}
+
+	return nil
+}
+
+// Resource directory of a module (starting with :) or the given path
+func sourceResDirectory(value string) (string, error) {
+	if strings.HasPrefix(value, ":") {
+		return internal.FindModuleResourcesDirectoryPath(value)
+	}
+
+	if _, err := internal.GetTranslationsFromResourceDirectory(value); err != nil {
+		return "", fmt.Errorf("%v is not an android resource directory", value)
+	}
+
+	return value, nil
+}
+
+// Resource directory of a module (starting with :) or the given path. A module
+// without resources gets them in its main source set.
+func destinationResDirectory(value string) (string, error) {
+	if !strings.HasPrefix(value, ":") {
+		return value, nil
+	}
+
+	dir, err := internal.FindModuleResourcesDirectoryPath(value)
+	if err == nil {
+		return dir, nil
+	}
+
+	moduleDir := internal.ModuleDirectory(value)
+	if info, statErr := os.Stat(moduleDir); statErr != nil || !info.IsDir() {
+		return "", err
+	}
+
+	resDirs, _ := internal.FindResourcesDirectoriesPath(moduleDir)
+	if len(resDirs) > 0 {
+		return "", err
+	}
+
+	return filepath.Join(moduleDir, "src", "main", "res"), nil
+}
+
+// Record the kinds of the resources of r by key
+func recordKinds(found map[string][]string, r internal.Resources) {
+	add := func(key, kind string) {
+		if !slices.Contains(found[key], kind) {
+			found[key] = append(found[key], kind)
+		}
+	}
+
+	for _, s := range r.Strings {
+		add(s.Key, internal.KindString)
+	}
+	for _, p := range r.Plurals {
+		add(p.Key, internal.KindPlurals)
+	}
+	for _, a := range r.StringArrays {
+		add(a.Key, internal.KindStringArray)
+	}
+}
+
+// References to the moved resources from modules that do not depend on the
+// destination module
+func invisibleReferences(found map[string][]string, destination string) ([]string, error) {
+	index, err := internal.BuildUsageIndex(".", androidUsageSources())
+	if err != nil {
+		return nil, err
+	}
+
+	references := []string{}
+	for _, key := range slices.Sorted(maps.Keys(found)) {
+		for _, kind := range found[key] {
+			usageKind := internal.UsageKind(kind)
+			for _, u := range index.Usages(usageKind, key) {
+				module := internal.ModuleOfPath(u.Path)
+				if internal.ModuleDependsOn(module, destination) {
+					continue
+				}
+
+				references = append(references, fmt.Sprintf("%v:%v: R.%v.%v in %v", internal.FindingPath(u.Path), u.Line, usageKind, key, module))
+			}
+		}
+	}
+
+	return references, nil
+}
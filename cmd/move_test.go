@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveCmd_non_android_project_directory(t *testing.T) {
+	root := &cobra.Command{Use: "move", RunE: moveCmd.RunE}
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, "current directory is not an android project", err.Error())
+}
+
+// Create a project with an :app module referencing the strings a and b and a
+// :feature:login module without resources
+func writeMoveProject(t *testing.T, appBuildFile string) {
+	writeProject(t, map[string]string{
+		"settings.gradle.kts":                    "include(\":app\", \":feature:login\")\n",
+		"app/build.gradle.kts":                   appBuildFile,
+		"app/src/main/res/values/strings.xml":    "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\">B</string>\n    <string name=\"c\">C</string>\n</resources>\n",
+		"app/src/main/res/values-pt/strings.xml": "<resources>\n    <string name=\"a\">A pt</string>\n    <string name=\"c\">C pt</string>\n</resources>\n",
+		"app/src/main/java/Main.kt":              "val a = R.string.a\nval c = R.string.c\n",
+		"feature/login/build.gradle.kts":         "",
+		"feature/login/src/main/java/Login.kt":   "val a = R.string.a\n",
+	})
+}
+
+func resetMoveFlags() {
+	moveKeys = []string{}
+	moveFrom = ""
+	moveTo = ""
+	dryRunM = false
+}
+
+func TestMoveCmd_move_to_module_without_resources(t *testing.T) {
+	writeMoveProject(t, "")
+
+	moveKeys = []string{"a", "b"}
+	moveFrom = ":app"
+	moveTo = ":feature:login"
+	defer resetMoveFlags()
+
+	var output bytes.Buffer
+	root := &cobra.Command{Use: "move", RunE: moveCmd.RunE}
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+
+	expected := map[string]string{
+		"app/src/main/res/values/strings.xml":              "<resources>\n    <string name=\"c\">C</string>\n</resources>\n",
+		"app/src/main/res/values-pt/strings.xml":           "<resources>\n    <string name=\"c\">C pt</string>\n</resources>\n",
+		"feature/login/src/main/res/values/strings.xml":    "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\">B</string>\n</resources>\n",
+		"feature/login/src/main/res/values-pt/strings.xml": "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n    <string name=\"a\">A pt</string>\n</resources>\n",
+	}
+	for path, content := range expected {
+		got, _ := os.ReadFile(path)
+		assert.Equal(t, content, string(got), path)
+	}
+
+	assert.Contains(t, output.String(), "Found 1 references in modules that do not depend on :feature:login")
+	assert.Contains(t, output.String(), "app/src/main/java/Main.kt:1: R.string.a in :app")
+}
+
+func TestMoveCmd_keeps_namespaces_and_attributes(t *testing.T) {
+	writeProject(t, map[string]string{
+		"settings.gradle.kts":  "include(\":app\", \":feature:login\")\n",
+		"app/build.gradle.kts": "",
+		appStringsPath("values"): "<resources xmlns:tools=\"http://schemas.android.com/tools\" xmlns:xliff=\"urn:oasis:names:tc:xliff:document:1.2\">\n" +
+			"    <string name=\"a\" formatted=\"false\">Hi <xliff:g id=\"name\">%s</xliff:g> %s</string>\n" +
+			"    <string name=\"b\" tools:ignore=\"MissingTranslation\">B</string>\n" +
+			"    <string name=\"c\">C</string>\n" +
+			"</resources>\n",
+		"feature/login/build.gradle.kts":                "",
+		"feature/login/src/main/res/values/strings.xml": "<resources>\n    <string name=\"c_login\">Login</string>\n</resources>\n",
+		appStringsPath("values-pt"):                     "<resources xmlns:xliff=\"urn:oasis:names:tc:xliff:document:1.2\">\n    <string name=\"a\">Oi <xliff:g id=\"name\">%s</xliff:g></string>\n</resources>\n",
+	})
+
+	moveKeys = []string{"a", "b"}
+	moveFrom = ":app"
+	moveTo = ":feature:login"
+	defer resetMoveFlags()
+
+	root := &cobra.Command{Use: "move", RunE: moveCmd.RunE}
+	root.SetOut(&bytes.Buffer{})
+
+	assert.NoError(t, root.Execute())
+
+	expected := map[string]string{
+		"feature/login/src/main/res/values/strings.xml": "<resources xmlns:tools=\"http://schemas.android.com/tools\" xmlns:xliff=\"urn:oasis:names:tc:xliff:document:1.2\">\n" +
+			"    <string name=\"a\" formatted=\"false\">Hi <xliff:g id=\"name\">%s</xliff:g> %s</string>\n" +
+			"    <string name=\"b\" tools:ignore=\"MissingTranslation\">B</string>\n" +
+			"    <string name=\"c_login\">Login</string>\n" +
+			"</resources>\n",
+		"feature/login/src/main/res/values-pt/strings.xml": "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources xmlns:xliff=\"urn:oasis:names:tc:xliff:document:1.2\">\n" +
+			"    <string name=\"a\">Oi <xliff:g id=\"name\">%s</xliff:g></string>\n" +
+			"</resources>\n",
+	}
+	for path, content := range expected {
+		got, _ := os.ReadFile(path)
+		assert.Equal(t, content, string(got), path)
+	}
+}
+
+func TestMoveCmd_dependent_module_sees_the_resources(t *testing.T) {
+	writeMoveProject(t, "dependencies {\n    implementation(projects.feature.login)\n}\n")
+
+	moveKeys = []string{"a"}
+	moveFrom = "app/src/main/res"
+	moveTo = ":feature:login"
+	dryRunM = true
+	defer resetMoveFlags()
+
+	var output bytes.Buffer
+	root := &cobra.Command{Use: "move", RunE: moveCmd.RunE}
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+	assert.Contains(t, output.String(), "+++ b/feature/login/src/main/res/values/strings.xml")
+	assert.NotContains(t, output.String(), "will no longer see the resources")
+
+	_, err := os.Stat("feature/login/src/main/res")
+	assert.True(t, os.IsNotExist(err), "Should not create the files")
+}
+
+func TestMoveCmd_key_conflict(t *testing.T) {
+	writeMoveProject(t, "")
+	os.MkdirAll("feature/login/src/main/res/values-pt", 0o755)
+	os.WriteFile("feature/login/src/main/res/values-pt/strings.xml", []byte("<resources>\n    <string name=\"a\">A</string>\n</resources>\n"), 0o644)
+
+	moveKeys = []string{"a"}
+	moveFrom = ":app"
+	moveTo = "feature/login/src/main/res"
+	defer resetMoveFlags()
+
+	root := &cobra.Command{Use: "move", RunE: moveCmd.RunE}
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, "key <a> already exists in feature/login/src/main/res/values-pt/strings.xml", err.Error())
+
+	content, _ := os.ReadFile("app/src/main/res/values/strings.xml")
+	assert.Contains(t, string(content), "<string name=\"a\">A</string>", "Should not change any file")
+}
+
+func TestMoveCmd_key_not_found(t *testing.T) {
+	writeMoveProject(t, "")
+
+	moveKeys = []string{"z"}
+	moveFrom = ":app"
+	moveTo = ":feature:login"
+	defer resetMoveFlags()
+
+	root := &cobra.Command{Use: "move", RunE: moveCmd.RunE}
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, "key <z> not found in app/src/main/res", err.Error())
+}
@@ -11,10 +11,9 @@ import (
 var allModulesN bool
 
 func init() {
-	// TODO: Add flags to select which type of normalization to apply
-	// If none selected apply all
 	rootCmd.AddCommand(normalizeCmd)
 	normalizeCmd.Flags().BoolVar(&allModulesN, "all", false, "Normalize all translations files of all project modules")
+	addResDirectoryFlags(normalizeCmd)
 }
 
 var normalizeCmd = &cobra.Command{
@@ -29,7 +28,22 @@ func runNormalizeCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	translations, err := internal.GetTranslations(allModulesN)
+	err = loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if !projectConfig.SortsByKey() {
+		fmt.Printf("Sorting is disabled by the sort policy of %v, nothing to normalize\n", projectConfig.Path)
+		return nil
+	}
+
+	resDirectory, err := resDirectoryFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	translations, err := internal.GetTranslations(allModulesN, resDirectory)
 	if err != nil || translations == nil {
 		if err != nil {
 			return err
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Write the files, by path relative to the project root, to a temporary
+// project and change to its directory until the test finishes
+func writeProject(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oldDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(oldDir) })
+
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Path of the strings.xml of the values directory in the app module
+func appStringsPath(valuesDir string) string {
+	return filepath.Join("app", "src", "main", "res", valuesDir, "strings.xml")
+}
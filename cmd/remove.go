@@ -2,15 +2,32 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"slices"
 
 	"polyglot/cmd/internal"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	removeUnused  bool
+	allModulesR   bool
+	keepKeys      []string
+	dryRunR       bool
+	confirmRemove bool
+)
+
 func init() {
 	rootCmd.AddCommand(removeCmd)
 	removeCmd.Flags().StringP("key", "k", "", "Key of the string to be removed")
+	removeCmd.Flags().BoolVar(&removeUnused, "unused", false, "Remove every string that check reports as unused")
+	removeCmd.Flags().BoolVar(&allModulesR, "all", false, "Remove unused strings from the resource directories of all project modules")
+	removeCmd.Flags().StringSliceVar(&keepKeys, "keep", []string{}, fmt.Sprintf("Keys never removed by --unused, glob patterns such as promo_* are accepted, in addition to the keep_keys of %v", internal.ConfigFileName))
+	removeCmd.Flags().BoolVar(&dryRunR, "dry-run", false, "Print the diff of the files instead of changing them")
+	removeCmd.Flags().BoolVarP(&confirmRemove, "yes", "y", false, "Remove every unused string without asking which ones")
+	removeCmd.MarkFlagsMutuallyExclusive("key", "unused")
+	addResDirectoryFlags(removeCmd)
 }
 
 var removeCmd = &cobra.Command{
@@ -25,12 +42,26 @@ func runRemoveCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	err = loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if removeUnused {
+		return runRemoveUnused(cmd)
+	}
+
 	key := cmd.Flag("key").Value.String()
 	if !internal.IsKeyValidPrintMessage(key) {
 		return fmt.Errorf("invalid key")
 	}
 
-	translations, err := internal.SingleSelectResDirectoryAndReturnTranslations()
+	resDirectory, err := resDirectoryFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	translations, err := internal.SelectResDirectoryAndReturnTranslations(resDirectory)
 	if err != nil || translations == nil {
 		if err != nil {
 			return err
@@ -49,12 +80,12 @@ func runRemoveCmd(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		if !r.ContainsStringByKey(key) {
+		if !r.ContainsResourceByKey(key) {
 			fmt.Printf("Key <%v> not found in %v\n", key, t.Path)
 			continue
 		}
 
-		r = r.RemoveStringByKey(key)
+		r = r.RemoveResourceByKey(key)
 
 		err = r.UpdateResourcesToXMLFile(t.Path)
 		if err != nil {
@@ -67,3 +98,116 @@ func runRemoveCmd(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// Remove the strings that are not referenced in the source tree from every
+// locale file of the selected resource directories
+func runRemoveUnused(cmd *cobra.Command) error {
+	for _, pattern := range keepKeys {
+		if !internal.IsValidKeyPattern(pattern) {
+			return fmt.Errorf("invalid --keep pattern %q", pattern)
+		}
+	}
+
+	resDirectory, err := resDirectoryFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	translations, err := internal.GetTranslations(allModulesR, resDirectory)
+	if err != nil {
+		return err
+	}
+	if len(translations) == 0 {
+		return fmt.Errorf("no translations found")
+	}
+
+	allResources := internal.ListResources{}
+	for _, t := range translations {
+		r, err := internal.GetResourcesFromPathXML(t.Path)
+		if err != nil {
+			return err
+		}
+
+		allResources = append(allResources, r)
+	}
+
+	fmt.Fprintln(os.Stderr, "Searching for unused keys...")
+
+	isUsed, err := keyUsage(projectConfig.EnabledUsageSources(), keepKeys)
+	if err != nil {
+		return err
+	}
+
+	// Excluded keys are ignored by check, so they are never reported as unused
+	keys := []string{}
+	for _, f := range withoutExcludedKeys(allResources).UnusedFindings(isUsed) {
+		keys = append(keys, f.Key)
+	}
+	slices.Sort(keys)
+
+	if len(keys) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No unused keys found")
+		return nil
+	}
+
+	if dryRunR {
+		for _, r := range allResources {
+			diff, err := withoutStrings(r, keys).DiffXML(r.Translation.Path)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprint(cmd.OutOrStdout(), diff)
+		}
+
+		fmt.Fprintf(os.Stderr, "%v unused keys would be removed, run without --dry-run to remove them\n", len(keys))
+		return nil
+	}
+
+	if !confirmRemove {
+		if !internal.IsInteractive() {
+			return fmt.Errorf("found %v unused keys and stdin is not a terminal, remove them with --yes or preview them with --dry-run", len(keys))
+		}
+
+		keys, err = internal.MultiSelect("Select the unused keys to remove:", keys)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(keys) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No keys selected, nothing was removed")
+		return nil
+	}
+
+	for _, r := range allResources {
+		removed := 0
+		for _, key := range keys {
+			if r.ContainsStringByKey(key) {
+				removed++
+			}
+		}
+		if removed == 0 {
+			continue
+		}
+
+		err = withoutStrings(r, keys).UpdateResourcesToXMLFile(r.Translation.Path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Removed %v keys from %v\n", removed, r.Translation.Path)
+	}
+
+	return nil
+}
+
+// Copy of r without the strings of keys, r itself is not changed
+func withoutStrings(r internal.Resources, keys []string) internal.Resources {
+	r.Strings = slices.Clone(r.Strings)
+	for _, key := range keys {
+		r = r.RemoveStringByKey(key)
+	}
+
+	return r
+}
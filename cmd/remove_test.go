@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"bytes"
+	"os"
 	"testing"
 
+	"polyglot/cmd/internal"
+
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,3 +18,81 @@ func TestRemoveCmd_non_android_project_directory(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "current directory is not an android project", err.Error())
 }
+
+// Create an android project where only the string a is referenced
+func writeRemoveProject(t *testing.T) {
+	writeProject(t, map[string]string{
+		"build.gradle":                           "",
+		"app/src/main/res/values/strings.xml":    "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\">B</string>\n    <string name=\"promo_c\">C</string>\n</resources>\n",
+		"app/src/main/res/values-pt/strings.xml": "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\">B</string>\n</resources>\n",
+		"app/src/main/java/Main.kt":              "val a = R.string.a\n",
+	})
+}
+
+func resetRemoveUnusedFlags() {
+	removeUnused = false
+	keepKeys = []string{}
+	dryRunR = false
+	confirmRemove = false
+}
+
+func TestRemoveCmd_unused_dry_run(t *testing.T) {
+	writeRemoveProject(t)
+
+	removeUnused = true
+	dryRunR = true
+	defer resetRemoveUnusedFlags()
+
+	var output bytes.Buffer
+	root := &cobra.Command{Use: "remove", RunE: removeCmd.RunE}
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+
+	assert.Contains(t, output.String(), "--- a/app/src/main/res/values/strings.xml")
+	assert.Contains(t, output.String(), "-    <string name=\"b\">B</string>")
+	assert.Contains(t, output.String(), "-    <string name=\"promo_c\">C</string>")
+	assert.Contains(t, output.String(), "--- a/app/src/main/res/values-pt/strings.xml")
+	assert.NotContains(t, output.String(), "-    <string name=\"a\">A</string>")
+
+	content, _ := os.ReadFile("app/src/main/res/values/strings.xml")
+	assert.Contains(t, string(content), "<string name=\"b\">B</string>", "Should not change the files")
+}
+
+func TestRemoveCmd_unused_keep(t *testing.T) {
+	writeRemoveProject(t)
+
+	removeUnused = true
+	confirmRemove = true
+	keepKeys = []string{"promo_*"}
+	defer resetRemoveUnusedFlags()
+
+	var output bytes.Buffer
+	root := &cobra.Command{Use: "remove", RunE: removeCmd.RunE}
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+
+	content, _ := os.ReadFile("app/src/main/res/values/strings.xml")
+	assert.Equal(t, "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"promo_c\">C</string>\n</resources>\n", string(content))
+
+	content, _ = os.ReadFile("app/src/main/res/values-pt/strings.xml")
+	assert.Equal(t, "<resources>\n    <string name=\"a\">A</string>\n</resources>\n", string(content))
+}
+
+func TestRemoveCmd_unused_requires_confirmation(t *testing.T) {
+	writeRemoveProject(t)
+
+	removeUnused = true
+	defer resetRemoveUnusedFlags()
+
+	isInteractive := internal.IsInteractive
+	internal.IsInteractive = func() bool { return false }
+	defer func() { internal.IsInteractive = isInteractive }()
+
+	root := &cobra.Command{Use: "remove", RunE: removeCmd.RunE}
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, "found 2 unused keys and stdin is not a terminal, remove them with --yes or preview them with --dry-run", err.Error())
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	renameFrom string
+	renameTo   string
+	dryRunRn   bool
+)
+
+func init() {
+	rootCmd.AddCommand(renameCmd)
+	renameCmd.Flags().StringVar(&renameFrom, "from", "", "Current key of the resource")
+	renameCmd.Flags().StringVar(&renameTo, "to", "", "New key of the resource")
+	renameCmd.Flags().BoolVar(&dryRunRn, "dry-run", false, "Print the diff of the files instead of changing them")
+	addResDirectoryFlags(renameCmd)
+}
+
+var renameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename a key in all files of a resource directory and in the code referencing it",
+	RunE:  runRenameCmd,
+}
+
+func runRenameCmd(cmd *cobra.Command, args []string) error {
+	err := internal.BlockIfNotAndroidProject()
+	if err != nil {
+		return err
+	}
+
+	err = loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if renameFrom == "" || renameTo == "" {
+		return fmt.Errorf("you need to pass the keys through --from and --to flags to use this command")
+	}
+	if !internal.IsStringKeyValid(renameTo) {
+		return fmt.Errorf("invalid key %q, only lowercase letters and underscores are allowed", renameTo)
+	}
+	if renameFrom == renameTo {
+		return fmt.Errorf("--from and --to are the same key")
+	}
+
+	resDirectory, err := resDirectoryFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	translations, err := internal.SelectResDirectoryAndReturnTranslations(resDirectory)
+	if err != nil {
+		return err
+	}
+	if len(translations) == 0 {
+		return fmt.Errorf("no translations found")
+	}
+
+	changes := internal.NewFileChanges()
+
+	// Kinds of the renamed resources, as a string and a plurals can share a key
+	kinds := []string{}
+	for _, t := range translations {
+		r, err := internal.GetResourcesFromPathXML(t.Path)
+		if err != nil {
+			return err
+		}
+
+		if r.ContainsResourceByKey(renameTo) {
+			return fmt.Errorf("key <%v> already exists in %v", renameTo, t.Path)
+		}
+
+		for kind, found := range map[string]bool{
+			internal.KindString:      r.ContainsStringByKey(renameFrom),
+			internal.KindPlurals:     r.ContainsPluralsByKey(renameFrom),
+			internal.KindStringArray: r.ContainsStringArrayByKey(renameFrom),
+		} {
+			if found && !slices.Contains(kinds, kind) {
+				kinds = append(kinds, kind)
+			}
+		}
+
+		if !r.ContainsResourceByKey(renameFrom) {
+			continue
+		}
+
+		output, err := r.RenameResourceByKey(renameFrom, renameTo, projectConfig.SortsByKey()).RenderRenamedXML(t.Path, renameFrom, renameTo)
+		if err != nil {
+			return err
+		}
+
+		err = changes.Set(t.Path, output)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(kinds) == 0 {
+		return fmt.Errorf("key <%v> not found in the resource directory", renameFrom)
+	}
+	slices.Sort(kinds)
+
+	references, err := renameReferences(changes, kinds)
+	if err != nil {
+		return err
+	}
+
+	if dryRunRn {
+		diff, err := changes.Diff()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(cmd.OutOrStdout(), diff)
+		fmt.Fprintf(os.Stderr, "%v files would be changed, run without --dry-run to change them\n", len(changes.Paths()))
+		return nil
+	}
+
+	err = changes.Write()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range changes.Paths() {
+		fmt.Fprintf(cmd.OutOrStdout(), "Updated %v\n", p)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Renamed <%v> to <%v> and %v references in the code\n", renameFrom, renameTo, references)
+
+	return nil
+}
+
+// Stage the rename of the references to the resources of the given kinds in
+// the Kotlin, Java and XML files of the project
+func renameReferences(changes *internal.FileChanges, kinds []string) (int, error) {
+	sources := androidUsageSources()
+
+	index, err := internal.BuildUsageIndex(".", sources)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for _, kind := range kinds {
+		usageKind := internal.UsageKind(kind)
+
+		paths := []string{}
+		for _, u := range index.Usages(usageKind, renameFrom) {
+			if !slices.Contains(paths, u.Path) {
+				paths = append(paths, u.Path)
+			}
+		}
+
+		for _, p := range paths {
+			content, err := changes.Read(p)
+			if err != nil {
+				return 0, err
+			}
+
+			content, renamed := internal.RenameReferences(p, content, sources, usageKind, renameFrom, renameTo)
+			if renamed == 0 {
+				continue
+			}
+
+			err = changes.Set(p, content)
+			if err != nil {
+				return 0, err
+			}
+			total += renamed
+		}
+	}
+
+	return total, nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameCmd_non_android_project_directory(t *testing.T) {
+	root := &cobra.Command{Use: "rename", RunE: renameCmd.RunE}
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, "current directory is not an android project", err.Error())
+}
+
+// Create an android project where the string b is referenced from Kotlin, Java and XML
+func writeRenameProject(t *testing.T) {
+	writeProject(t, map[string]string{
+		"build.gradle":                           "",
+		"app/src/main/res/values/strings.xml":    "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\">B</string>\n    <string name=\"d\">D</string>\n</resources>\n",
+		"app/src/main/res/values-pt/strings.xml": "<resources>\n    <string name=\"b\">B</string>\n    <string name=\"a\">A</string>\n</resources>\n",
+		"app/src/main/res/layout/main.xml":       "<TextView android:text=\"@string/b\" android:hint=\"@string/bb\" />\n",
+		"app/src/main/java/Main.kt":              "import com.palmeiras.R as AppR\n\nval b = AppR.string.b + R.string.bb\n",
+		"app/src/main/java/Other.java":           "String b = getString(R.string.b);\n",
+		"app/src/main/java/Multiplatform.kt":     "val b = Res.string.b\n",
+		"app/src/main/res/values-es/strings.xml": "<resources>\n    <string name=\"a\">A</string>\n</resources>\n",
+	})
+}
+
+func resetRenameFlags() {
+	renameFrom = ""
+	renameTo = ""
+	dryRunRn = false
+}
+
+func TestRenameCmd_rename_resources_and_references(t *testing.T) {
+	writeRenameProject(t)
+
+	renameFrom = "b"
+	renameTo = "c"
+	defer resetRenameFlags()
+
+	var output bytes.Buffer
+	root := &cobra.Command{Use: "rename", RunE: renameCmd.RunE}
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+	assert.Contains(t, output.String(), "Renamed <b> to <c> and 3 references in the code")
+
+	expected := map[string]string{
+		"app/src/main/res/values/strings.xml":    "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"c\">B</string>\n    <string name=\"d\">D</string>\n</resources>\n",
+		"app/src/main/res/values-pt/strings.xml": "<resources>\n    <string name=\"c\">B</string>\n    <string name=\"a\">A</string>\n</resources>\n",
+		"app/src/main/res/layout/main.xml":       "<TextView android:text=\"@string/c\" android:hint=\"@string/bb\" />\n",
+		"app/src/main/java/Main.kt":              "import com.palmeiras.R as AppR\n\nval b = AppR.string.c + R.string.bb\n",
+		"app/src/main/java/Other.java":           "String b = getString(R.string.c);\n",
+		"app/src/main/java/Multiplatform.kt":     "val b = Res.string.b\n",
+		"app/src/main/res/values-es/strings.xml": "<resources>\n    <string name=\"a\">A</string>\n</resources>\n",
+	}
+	for path, content := range expected {
+		got, _ := os.ReadFile(path)
+		assert.Equal(t, content, string(got), path)
+	}
+}
+
+func TestRenameCmd_dry_run(t *testing.T) {
+	writeRenameProject(t)
+
+	renameFrom = "b"
+	renameTo = "c"
+	dryRunRn = true
+	defer resetRenameFlags()
+
+	var output bytes.Buffer
+	root := &cobra.Command{Use: "rename", RunE: renameCmd.RunE}
+	root.SetOut(&output)
+
+	assert.NoError(t, root.Execute())
+	assert.Contains(t, output.String(), "-    <string name=\"b\">B</string>\n+    <string name=\"c\">B</string>")
+	assert.Contains(t, output.String(), "+++ b/app/src/main/java/Other.java")
+
+	content, _ := os.ReadFile("app/src/main/java/Other.java")
+	assert.Equal(t, "String b = getString(R.string.b);\n", string(content), "Should not change the files")
+}
+
+func TestRenameCmd_key_conflict(t *testing.T) {
+	writeRenameProject(t)
+
+	renameFrom = "b"
+	renameTo = "a"
+	defer resetRenameFlags()
+
+	root := &cobra.Command{Use: "rename", RunE: renameCmd.RunE}
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "key <a> already exists in")
+}
+
+func TestRenameCmd_keeps_attributes(t *testing.T) {
+	writeProject(t, map[string]string{
+		"build.gradle": "",
+		appStringsPath("values"): "<resources xmlns:tools=\"http://schemas.android.com/tools\">\n" +
+			"    <string name=\"a_title\">Title</string>\n" +
+			"    <string name=\"b_hello\" formatted=\"false\" tools:ignore=\"MissingTranslation\">Hello %s %s</string>\n" +
+			"    <plurals name='b_hello' tools:ignore=\"UnusedQuantity\">\n        <item quantity=\"other\">Hellos</item>\n    </plurals>\n" +
+			"</resources>\n",
+	})
+
+	renameFrom = "b_hello"
+	renameTo = "d_hello"
+	defer resetRenameFlags()
+
+	root := &cobra.Command{Use: "rename", RunE: renameCmd.RunE}
+	root.SetOut(&bytes.Buffer{})
+
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile(appStringsPath("values"))
+	expected := "<resources xmlns:tools=\"http://schemas.android.com/tools\">\n" +
+		"    <string name=\"a_title\">Title</string>\n" +
+		"    <string name=\"d_hello\" formatted=\"false\" tools:ignore=\"MissingTranslation\">Hello %s %s</string>\n" +
+		"    <plurals name='d_hello' tools:ignore=\"UnusedQuantity\">\n        <item quantity=\"other\">Hellos</item>\n    </plurals>\n" +
+		"</resources>\n"
+	assert.Equal(t, expected, string(got))
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	allModulesS bool
+	dryRunS     bool
+	onlyLocales []string
+)
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().BoolVar(&allModulesS, "all", false, "Sync translations files of all project modules")
+	syncCmd.Flags().BoolVar(&dryRunS, "dry-run", false, "Only print the missing keys, do not translate or write to the files")
+	syncCmd.Flags().StringSliceVar(&onlyLocales, "only-locale", []string{}, "Only sync the given locales, as a qualifier (pt-rBR) or a language (pt), comma separated or repeated")
+	addTranslatorFlags(syncCmd)
+	addResDirectoryFlags(syncCmd)
+}
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Translate every key of the default strings.xml that is missing from the other locales",
+	RunE:  runSyncCmd,
+}
+
+func runSyncCmd(cmd *cobra.Command, args []string) error {
+	err := internal.BlockIfNotAndroidProject()
+	if err != nil {
+		return err
+	}
+
+	err = loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	var translator internal.Translator
+	if !dryRunS {
+		translator, err = newTranslatorFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	resDirectory, err := resDirectoryFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	translations, err := internal.GetTranslations(allModulesS, resDirectory)
+	if err != nil || translations == nil {
+		if err != nil {
+			return err
+		}
+		if translations != nil {
+			return fmt.Errorf("no translations found")
+		}
+	}
+
+	for _, directory := range internal.GroupTranslationsByResourceDirectory(translations) {
+		fmt.Printf("Syncing %v...\n", directory.Path)
+
+		defaultTranslation, ok := directory.DefaultTranslation()
+		if !ok {
+			fmt.Printf("\tNo default values/strings.xml found, skipping\n\n")
+			continue
+		}
+
+		source, err := internal.GetResourcesFromPathXML(defaultTranslation.Path)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		source = source.WithoutKeys(projectConfig.IsKeyExcluded)
+
+		for _, t := range directory.Translations {
+			if t.IsDefault() || !isLocaleSelected(t) {
+				continue
+			}
+
+			err := syncTranslation(translator, source, t)
+			if err != nil {
+				fmt.Printf("\tError syncing %v: %v\n", t.Path, err)
+			}
+		}
+
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// Locales given with --only-locale or, if not set, the target locales of the project configuration
+func isLocaleSelected(t internal.Translation) bool {
+	if len(onlyLocales) == 0 {
+		return projectConfig.IsTargetLocale(t)
+	}
+
+	return slices.ContainsFunc(onlyLocales, t.MatchesLocale)
+}
+
+// Translate the resources of source missing in the translation with as few calls to the provider as its limits allow
+func syncTranslation(translator internal.Translator, source internal.Resources, t internal.Translation) error {
+	r, err := internal.GetResourcesFromPathXML(t.Path)
+	if err != nil {
+		return err
+	}
+
+	missing := r.MissingResourcesFrom(source)
+
+	count := len(missing.Strings) + len(missing.StringArrays)
+	fmt.Printf("\t%v (%v): %v missing keys\n", t.Qualifier(), t.Language, count)
+
+	// Each language has its own set of quantities, so plurals are not guessed
+	for _, p := range missing.Plurals {
+		fmt.Printf("\t\tSkipping plurals <%v>, translate it manually\n", p.Key)
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	if translator == nil {
+		for _, s := range missing.Strings {
+			fmt.Printf("\t\t+ %v: %v\n", s.Key, s.Value)
+		}
+		for _, a := range missing.StringArrays {
+			fmt.Printf("\t\t+ %v: %v\n", a.Key, a.Values())
+		}
+		return nil
+	}
+
+	texts := []string{}
+	for _, s := range missing.Strings {
+		texts = append(texts, s.Value)
+	}
+	for _, a := range missing.StringArrays {
+		texts = append(texts, a.Values()...)
+	}
+
+	translated, errs, err := internal.TranslateEachText(translator, texts, projectConfig.SourceTranslation(), t)
+	if err != nil {
+		return err
+	}
+
+	// A key whose placeholders the provider broke is skipped, the others are still added
+	added := internal.Resources{}
+	for i, s := range missing.Strings {
+		if errs[i] != nil {
+			fmt.Printf("\t\tSkipping <%v>: %v\n", s.Key, errs[i])
+			continue
+		}
+
+		added.Strings = append(added.Strings, internal.String{
+			XMLName: xml.Name{Local: "string"},
+			Key:     s.Key,
+			Value:   translated[i],
+		})
+		fmt.Printf("\t\t+ %v: %v\n", s.Key, translated[i])
+	}
+
+	next := len(missing.Strings)
+	for _, a := range missing.StringArrays {
+		items := translated[next : next+len(a.Items)]
+		itemErr := errors.Join(errs[next : next+len(a.Items)]...)
+		next += len(a.Items)
+
+		if itemErr != nil {
+			fmt.Printf("\t\tSkipping <%v>: %v\n", a.Key, itemErr)
+			continue
+		}
+
+		added.StringArrays = append(added.StringArrays, internal.NewStringArray(a.Key, items))
+		fmt.Printf("\t\t+ %v: %v\n", a.Key, items)
+	}
+
+	if len(added.Strings)+len(added.StringArrays) == 0 {
+		return nil
+	}
+
+	r = r.AddResources(added, projectConfig.SortsByKey())
+
+	return r.UpdateResourcesToXMLFile(t.Path)
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"polyglot/cmd/internal"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncCmd_non_android_project_directory(t *testing.T) {
+	root := &cobra.Command{Use: "sync", RunE: syncCmd.RunE}
+	err := root.Execute()
+
+	assert.Error(t, err)
+	assert.Equal(t, "current directory is not an android project", err.Error())
+}
+
+type fakeTranslator struct {
+	calls int
+	// Masked text the translator loses the placeholders of
+	broken string
+}
+
+func (f *fakeTranslator) Name() string {
+	return "fake"
+}
+
+// Prefix every text with the locale of the target
+func (f *fakeTranslator) Translate(ctx context.Context, texts []string, source, target internal.Translation) ([]string, error) {
+	f.calls++
+
+	translated := []string{}
+	for _, text := range texts {
+		if text == f.broken {
+			translated = append(translated, target.LocaleCode)
+			continue
+		}
+		translated = append(translated, target.LocaleCode+" "+text)
+	}
+	return translated, nil
+}
+
+func (f *fakeTranslator) SupportedLanguages(ctx context.Context) ([]string, error) {
+	return []string{"pt", "es"}, nil
+}
+
+func writeSyncProject(t *testing.T) {
+	writeProject(t, map[string]string{
+		"build.gradle":              "",
+		appStringsPath("values"):    "<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\">B</string>\n    <string-array name=\"c\">\n        <item>C1</item>\n        <item>C2</item>\n    </string-array>\n</resources>\n",
+		appStringsPath("values-pt"): "<resources>\n    <string name=\"a\">A pt</string>\n</resources>\n",
+		appStringsPath("values-es"): "<resources>\n</resources>\n",
+	})
+}
+
+func syncSource(t *testing.T) internal.Resources {
+	source, err := internal.GetResourcesFromPathXML(appStringsPath("values"))
+	assert.NoError(t, err)
+
+	return source
+}
+
+func TestSyncTranslation(t *testing.T) {
+	writeSyncProject(t)
+
+	translator := &fakeTranslator{}
+	pt := internal.Translation{Path: appStringsPath("values-pt"), LocaleCode: "pt", Language: "Portuguese"}
+
+	assert.NoError(t, syncTranslation(translator, syncSource(t), pt))
+	assert.Equal(t, 1, translator.calls)
+
+	got, _ := os.ReadFile(pt.Path)
+	assert.Contains(t, string(got), `<string name="b">pt B</string>`)
+	assert.Contains(t, string(got), "<item>pt C1</item>")
+	assert.Contains(t, string(got), "<item>pt C2</item>")
+	assert.Contains(t, string(got), `<string name="a">A pt</string>`)
+}
+
+func TestSyncTranslation_skips_broken_placeholders(t *testing.T) {
+	writeSyncProject(t)
+	os.WriteFile(appStringsPath("values"), []byte("<resources>\n    <string name=\"a\">A</string>\n    <string name=\"b\">B</string>\n    <string name=\"d\">%1$d items</string>\n</resources>\n"), 0o644)
+
+	translator := &fakeTranslator{broken: "__PH0__ items"}
+	pt := internal.Translation{Path: appStringsPath("values-pt"), LocaleCode: "pt", Language: "Portuguese"}
+
+	assert.NoError(t, syncTranslation(translator, syncSource(t), pt))
+
+	got, _ := os.ReadFile(pt.Path)
+	assert.Equal(t, "<resources>\n    <string name=\"a\">A pt</string>\n    <string name=\"b\">pt B</string>\n</resources>\n", string(got))
+}
+
+func TestSyncTranslation_unsorted_file(t *testing.T) {
+	writeSyncProject(t)
+	os.WriteFile(appStringsPath("values-pt"), []byte("<resources>\n    <string name=\"z\">Z pt</string>\n    <string name=\"a\">A pt</string>\n</resources>\n"), 0o644)
+
+	pt := internal.Translation{Path: appStringsPath("values-pt"), LocaleCode: "pt", Language: "Portuguese"}
+	assert.NoError(t, syncTranslation(&fakeTranslator{}, syncSource(t), pt))
+
+	// The file is not sorted, so the keys are appended instead of inserted
+	got, _ := os.ReadFile(pt.Path)
+	assert.Equal(t, "<resources>\n    <string name=\"z\">Z pt</string>\n    <string name=\"a\">A pt</string>\n    <string name=\"b\">pt B</string>\n    <string-array name=\"c\">\n        <item>pt C1</item>\n        <item>pt C2</item>\n    </string-array>\n</resources>\n", string(got))
+}
+
+func TestSyncTranslation_dry_run(t *testing.T) {
+	writeSyncProject(t)
+
+	// --dry-run syncs without a translator
+	pt := internal.Translation{Path: appStringsPath("values-pt"), LocaleCode: "pt", Language: "Portuguese"}
+	assert.NoError(t, syncTranslation(nil, syncSource(t), pt))
+
+	got, _ := os.ReadFile(pt.Path)
+	assert.Equal(t, "<resources>\n    <string name=\"a\">A pt</string>\n</resources>\n", string(got))
+}
+
+func TestSyncCmd_only_locale(t *testing.T) {
+	writeSyncProject(t)
+
+	onlyLocales = []string{"es"}
+	defer func() { onlyLocales = []string{} }()
+
+	assert.True(t, isLocaleSelected(internal.Translation{Path: appStringsPath("values-es"), LocaleCode: "es"}))
+	assert.False(t, isLocaleSelected(internal.Translation{Path: appStringsPath("values-pt"), LocaleCode: "pt"}))
+
+	dryRunS = true
+	defer func() { dryRunS = false }()
+
+	root := &cobra.Command{Use: "sync", RunE: syncCmd.RunE}
+	root.Flags().String("res", "", "")
+	root.Flags().String("module", "", "")
+	assert.NoError(t, root.Execute())
+
+	got, _ := os.ReadFile(appStringsPath("values-es"))
+	assert.Equal(t, "<resources>\n</resources>\n", string(got))
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"encoding/xml"
 	"fmt"
+	"slices"
+	"strings"
 
 	"polyglot/cmd/internal"
 
@@ -18,9 +20,11 @@ func init() {
 	rootCmd.AddCommand(translateCmd)
 	translateCmd.Flags().StringP("key", "k", "", "Key to use for translation (no spaces allowed, lowercases letters and underscores only)")
 	translateCmd.Flags().StringP("value", "v", "", "String to translate (english only, closed in quotes)")
-	translateCmd.Flags().StringP("googleApiKey", "g", "", "Google Translate API Key (if not set it will use the GOOGLE_TRANSLATE_KEY environment variable)")
+	translateCmd.Flags().StringArrayP("item", "i", []string{}, "Item of a string-array to translate, repeat the flag for each item in order (english only, closed in quotes)")
+	addTranslatorFlags(translateCmd)
 	translateCmd.Flags().BoolVar(&force, "force", false, "Force translation even if the key already exists in the file by substituting the value for the new translated one")
 	translateCmd.Flags().BoolVar(&printOnly, "print-only", false, "Only print the translations, do not write to the files")
+	addResDirectoryFlags(translateCmd)
 }
 
 var translateCmd = &cobra.Command{
@@ -35,20 +39,34 @@ func runTranslateCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	err = loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
 	key := cmd.Flag("key").Value.String()
 	if !internal.IsKeyValidPrintMessage(key) {
 		return fmt.Errorf("invalid key")
 	}
 
 	str := cmd.Flag("value").Value.String()
-	googleApiKey := cmd.Flag("googleApiKey").Value.String()
+	items, _ := cmd.Flags().GetStringArray("item")
+	if str != "" && len(items) > 0 {
+		fmt.Println("Use --value to translate a string or --item to translate a string-array, not both.")
+		return fmt.Errorf("invalid value")
+	}
 
-	if googleApiKey == "" && !internal.ContainsGoogleApiKey() {
-		fmt.Println("You need to pass the key through --googleApiKey flag or set the GOOGLE_TRANSLATE_KEY environment variable to use this command.")
-		return fmt.Errorf("invalid googleApiKey")
+	translator, err := newTranslatorFromFlags(cmd)
+	if err != nil {
+		return err
 	}
 
-	translations, err := internal.SingleSelectResDirectoryAndReturnTranslations()
+	resDirectory, err := resDirectoryFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	translations, err := internal.SelectResDirectoryAndReturnTranslations(resDirectory)
 	if err != nil || translations == nil {
 		if err != nil {
 			return err
@@ -58,12 +76,16 @@ func runTranslateCmd(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	translations = slices.DeleteFunc(translations, func(t internal.Translation) bool {
+		return !t.IsDefault() && !projectConfig.IsTargetLocale(t)
+	})
+
 	languagesFound := []string{}
 	for _, s := range translations {
 		languagesFound = append(languagesFound, s.Language)
 	}
 
-	fmt.Printf("Languages found: %v\nTranslating...\n\n", languagesFound)
+	fmt.Printf("Languages found: %v\nTranslating with %v...\n\n", languagesFound, translator.Name())
 
 	for _, t := range translations {
 		r, err := internal.GetResourcesFromPathXML(t.Path)
@@ -72,18 +94,36 @@ func runTranslateCmd(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		if !printOnly && (r.ContainsStringByKey(key) && !force) {
-			fmt.Printf("Key <%v> already exists in %v\n", key, t.Path)
-			continue
+		alreadyExists := r.ContainsStringByKey(key)
+		if len(items) > 0 {
+			alreadyExists = r.ContainsStringArrayByKey(key)
 		}
 
-		translatedText, err := internal.TranslateText(str, t.LocaleCode, &googleApiKey)
-		if err != nil {
-			fmt.Println("Error translating to", t.Language)
+		if !printOnly && (alreadyExists && !force) {
+			fmt.Printf("Key <%v> already exists in %v\n", key, t.Path)
 			continue
 		}
 
-		r = addStringToResources(r, t, key, translatedText)
+		var translatedText string
+		if len(items) > 0 {
+			translatedItems, err := translateTextsTo(translator, items, t)
+			if err != nil {
+				fmt.Printf("Error translating to %v: %v\n", t.Language, err)
+				continue
+			}
+
+			r = addStringArrayToResources(r, t, key, translatedItems)
+			translatedText = fmt.Sprintf("[%v]", strings.Join(translatedItems, ", "))
+		} else {
+			translated, err := translateTextsTo(translator, []string{str}, t)
+			if err != nil {
+				fmt.Printf("Error translating to %v: %v\n", t.Language, err)
+				continue
+			}
+			translatedText = translated[0]
+
+			r = addStringToResources(r, t, key, translatedText)
+		}
 
 		if !printOnly {
 			err = r.UpdateResourcesToXMLFile(t.Path)
@@ -99,13 +139,30 @@ func runTranslateCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// Texts translated to the locale of t. The default locale is already in the
+// source language, so it gets the texts as they are without calling the
+// provider, which rejects translating a language to itself.
+func translateTextsTo(translator internal.Translator, texts []string, t internal.Translation) ([]string, error) {
+	if !t.IsDefault() {
+		return internal.TranslateTexts(translator, texts, projectConfig.SourceTranslation(), t)
+	}
+
+	// Escaped as the translations of the other locales are
+	values := []string{}
+	for _, text := range texts {
+		values = append(values, internal.ResourceValue(text))
+	}
+
+	return values, nil
+}
+
 func addStringToResources(r internal.Resources, t internal.Translation, key, translatedText string) internal.Resources {
 	if r.ContainsStringByKey(key) && force {
 		fmt.Printf("Substituting <%v> that already exists in %v\n", key, t.Path)
 		return r.CreateOrSubstituteStringByKey(key, translatedText)
 	}
 
-	if r.IsSortedByKey() {
+	if projectConfig.SortsByKey() && r.IsSortedByKey() {
 		return r.AddNewStringSorted(internal.String{
 			XMLName: xml.Name{Local: "string"},
 			Key:     key,
@@ -119,3 +176,13 @@ func addStringToResources(r internal.Resources, t internal.Translation, key, tra
 		Value:   translatedText,
 	})
 }
+
+func addStringArrayToResources(r internal.Resources, t internal.Translation, key string, translatedItems []string) internal.Resources {
+	if r.ContainsStringArrayByKey(key) && force {
+		fmt.Printf("Substituting <%v> that already exists in %v\n", key, t.Path)
+	} else if !projectConfig.SortsByKey() {
+		return r.AppendNewStringArray(internal.NewStringArray(key, translatedItems))
+	}
+
+	return r.CreateOrSubstituteStringArrayByKey(key, translatedItems)
+}
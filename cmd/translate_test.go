@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"path/filepath"
 	"testing"
 
+	"polyglot/cmd/internal"
+
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,3 +17,13 @@ func TestTranslateCmd_non_android_project_directory(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "current directory is not an android project", err.Error())
 }
+
+func TestTranslateTextsTo_default_locale(t *testing.T) {
+	defaultTranslation := internal.Translation{Path: filepath.Join("res", "values", "strings.xml"), LocaleCode: "en"}
+
+	// No provider is called for the default locale, a nil translator would panic
+	got, err := translateTextsTo(nil, []string{"Tom & Jerry's"}, defaultTranslation)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`Tom &amp; Jerry\'s`}, got)
+}
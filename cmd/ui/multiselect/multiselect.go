@@ -0,0 +1,143 @@
+package multiselect
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type model struct {
+	title     string
+	choices   []string
+	cursor    int
+	selection *Selection
+	confirmed bool
+}
+
+// Choices marked in the list and whether the user confirmed them
+type Selection struct {
+	Selected  map[int]bool
+	Confirmed bool
+}
+
+// Selection with every choice marked
+func InitialSelection(choices []string) Selection {
+	selected := map[int]bool{}
+	for i := range choices {
+		selected[i] = true
+	}
+
+	return Selection{Selected: selected, Confirmed: false}
+}
+
+// Marked choices in the order they were given
+func (s *Selection) Choices(choices []string) []string {
+	result := []string{}
+	for i, choice := range choices {
+		if s.Selected[i] {
+			result = append(result, choice)
+		}
+	}
+
+	return result
+}
+
+func (s *Selection) toggle(index int) {
+	s.Selected[index] = !s.Selected[index]
+}
+
+func (s *Selection) setAll(choices []string, selected bool) {
+	for i := range choices {
+		s.Selected[i] = selected
+	}
+}
+
+func (s *Selection) allSelected(choices []string) bool {
+	for i := range choices {
+		if !s.Selected[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func InitialModelMultiSelect(title string, choices []string, selection *Selection) model {
+	return model{
+		title:     title,
+		choices:   choices,
+		cursor:    0,
+		selection: selection,
+		confirmed: false,
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+
+		case "y", "Y":
+			m.confirmed = true
+			m.selection.Confirmed = true
+			return m, tea.Quit
+
+		case "ctrl+c", "q":
+			m.confirmed = false
+			m.selection.Confirmed = false
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down", "j":
+			if m.cursor < len(m.choices)-1 {
+				m.cursor++
+			}
+
+		case "enter", " ":
+			if len(m.choices) > 0 {
+				m.selection.toggle(m.cursor)
+			}
+
+		case "a":
+			m.selection.setAll(m.choices, !m.selection.allSelected(m.choices))
+		}
+	}
+
+	return m, nil
+}
+
+func (m model) View() string {
+	if m.confirmed {
+		return fmt.Sprintf("You selected %v of %v items\n", len(m.selection.Choices(m.choices)), len(m.choices))
+	}
+
+	s := m.title + "\n\n"
+
+	for i, choice := range m.choices {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+
+		checked := " "
+		if m.selection.Selected[i] {
+			checked = "x"
+		}
+
+		s += fmt.Sprintf("%s [%s] %s\n", cursor, checked, choice)
+	}
+
+	s += "\nPress space to toggle an item and a to toggle all of them."
+	s += "\nPress q to cancel."
+	s += "\nPress y to continue.\n"
+
+	return s
+}
@@ -0,0 +1,80 @@
+package multiselect
+
+import (
+	"testing"
+
+	teatest "github.com/charmbracelet/x/exp/teatest"
+	"github.com/stretchr/testify/assert"
+)
+
+var choices = []string{"app_name", "debug_menu", "palmeiras"}
+
+func setup(t *testing.T) (*teatest.TestModel, *Selection) {
+	selection := InitialSelection(choices)
+
+	m := InitialModelMultiSelect("Select the keys:", choices, &selection)
+
+	tm := teatest.NewTestModel(t, m, teatest.WithInitialTermSize(70, 30))
+	t.Cleanup(func() {
+		if err := tm.Quit(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	return tm, &selection
+}
+
+func TestMultiSelect_every_choice_selected_initially(t *testing.T) {
+	tm, selection := setup(t)
+
+	tm.Type("y")
+	tm.FinalModel(t)
+
+	assert.Equal(t, choices, selection.Choices(choices), "Should select every choice initially")
+	assert.True(t, selection.Confirmed, "Should confirm the selection")
+}
+
+func TestMultiSelect_unselect_a_choice(t *testing.T) {
+	tm, selection := setup(t)
+
+	tm.Type("j")
+	tm.Type(" ")
+	tm.Type("y")
+	tm.FinalModel(t)
+
+	assert.Equal(t, []string{"app_name", "palmeiras"}, selection.Choices(choices), "Should unselect the second choice")
+}
+
+func TestMultiSelect_toggle_all_choices(t *testing.T) {
+	tm, selection := setup(t)
+
+	tm.Type("a")
+	tm.Type("j")
+	tm.Type("j")
+	tm.Type(" ")
+	tm.Type("y")
+	tm.FinalModel(t)
+
+	assert.Equal(t, []string{"palmeiras"}, selection.Choices(choices), "Should unselect all choices and select the last one")
+}
+
+func TestMultiSelect_move_cursor_down_when_its_on_bottom(t *testing.T) {
+	tm, _ := setup(t)
+
+	tm.Type("j")
+	tm.Type("j")
+	tm.Type("j")
+	tm.Type("y")
+	if tm.FinalModel(t).(model).cursor != 2 {
+		assert.Fail(t, "Should not move the cursor down if it's already at the bottom")
+	}
+}
+
+func TestMultiSelect_close_without_confirming(t *testing.T) {
+	tm, selection := setup(t)
+
+	tm.Type("q")
+	tm.FinalModel(t)
+
+	assert.False(t, selection.Confirmed, "Should close the selection without confirming")
+}